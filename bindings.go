@@ -0,0 +1,61 @@
+package hx
+
+import "sort"
+
+func (element *VNode) BindAttribute(key string, signal Gettable[string]) INode {
+	element.ownEffect(func() {
+		value := signal.Get()
+		if len(value) == 0 {
+			element.RemoveAttribute(key)
+		} else {
+			element.Attribute(key, value)
+		}
+		element.scheludeRender()
+	})
+	return element
+}
+
+func (element *VNode) BindClass(class string, condition Gettable[bool]) INode {
+	element.ownEffect(func() {
+		if condition.Get() {
+			element.Class(class)
+		} else {
+			element.RemoveClass(class)
+		}
+		element.scheludeRender()
+	})
+	return element
+}
+
+func (element *VNode) ClassMap(classes map[string]Gettable[bool]) INode {
+	names := make([]string, 0, len(classes))
+	for name := range classes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		element.BindClass(name, classes[name])
+	}
+	return element
+}
+
+func (element *VNode) BindStyle(key string, signal Gettable[string]) INode {
+	element.ownEffect(func() {
+		value := signal.Get()
+		if len(value) == 0 {
+			element.RemoveStyle(key)
+		} else {
+			element.Style(key, value)
+		}
+		element.scheludeRender()
+	})
+	return element
+}
+
+func (element *VNode) BindProperty(key string, value func() any) INode {
+	element.ownEffect(func() {
+		element.Property(key, value())
+		element.scheludeRender()
+	})
+	return element
+}
@@ -0,0 +1,39 @@
+package hx
+
+import "testing"
+
+func TestBindClassToggleBeforeRender(t *testing.T) {
+	on := Signal(true)
+	element := Div()
+	element.BindClass("active", on)
+
+	on.Set(false)
+	on.Set(true)
+
+	if !element.HaveClass("active") {
+		t.Fatal("expected class to be present after toggling back on")
+	}
+}
+
+func TestBindAttributeAndStyleToggleBeforeRender(t *testing.T) {
+	value := Signal("x")
+	element := Div()
+	element.BindAttribute("title", value)
+	element.BindStyle("color", value)
+
+	value.Set("")
+	value.Set("x")
+
+	if got := element.GetAttribute("title"); got != "x" {
+		t.Errorf("expected title attribute x, got %q", got)
+	}
+	if got := element.GetStyle("color"); got != "x" {
+		t.Errorf("expected color style x, got %q", got)
+	}
+
+	renderer := &StringRenderer{}
+	renderer.Render(element)
+	if got, want := renderer.String(), `<DIV style="color:x;" title="x"></DIV>`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,151 @@
+package hx
+
+import (
+	"fmt"
+	"slices"
+)
+
+type boundaryKey struct{}
+
+type suspenseKey struct{}
+
+type errorBoundary struct {
+	err     error
+	version *SignalT[int]
+}
+
+func boundaryOf(effect *Effect) *errorBoundary {
+	boundary, _ := lookupScope(effect, boundaryKey{}).(*errorBoundary)
+	return boundary
+}
+
+func currentBoundary() *errorBoundary {
+	var boundary *errorBoundary
+	accessEffect(func(currentEffect *Effect) {
+		boundary = boundaryOf(currentEffect)
+	})
+	return boundary
+}
+
+// capture runs fn and turns a panic into an error when there is a
+// boundary to report it to. Without one the panic goes on as usual.
+func (boundary *errorBoundary) capture(fn func()) (err error) {
+	if boundary == nil {
+		fn()
+		return nil
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = panicError(recovered)
+		}
+	}()
+	fn()
+	return nil
+}
+
+func (boundary *errorBoundary) catch(fn func()) {
+	if err := boundary.capture(fn); err != nil {
+		boundary.fail(err)
+	}
+}
+
+func (boundary *errorBoundary) fail(err error) {
+	if boundary.err != nil {
+		return
+	}
+	boundary.err = err
+	boundary.version.Update(func(version int) int { return version + 1 })
+}
+
+func (boundary *errorBoundary) reset() {
+	boundary.err = nil
+	boundary.version.Update(func(version int) int { return version + 1 })
+}
+
+func panicError(recovered any) error {
+	if err, ok := recovered.(error); ok {
+		return err
+	}
+	return fmt.Errorf("panic: %v", recovered)
+}
+
+// ErrorBoundary renders child and swaps it for fallback when rendering
+// it, one of its effects or one of its event handlers panics. Calling
+// reset renders child again from scratch.
+func ErrorBoundary(fallback func(err error, reset func()) INode, child func() INode) INode {
+	boundary := &errorBoundary{version: Signal(0)}
+	return renderBranch(func() func() INode {
+		boundary.version.Get()
+		if err := boundary.err; err != nil {
+			return func() INode { return fallback(err, boundary.reset) }
+		}
+		return func() INode {
+			var node INode
+			err := boundary.capture(func() {
+				runInScope(boundaryKey{}, boundary, func() {
+					node = child()
+				})
+			})
+			if err == nil {
+				err = boundary.err
+			}
+			if err != nil {
+				boundary.err = err
+				return fallback(err, boundary.reset)
+			}
+			return node
+		}
+	})
+}
+
+type suspense struct {
+	pending *SignalT[[]*SignalT[bool]]
+	readers map[*SignalT[bool]]int
+}
+
+// track waits for loading until the effect reading it is cleaned up.
+func (suspense *suspense) track(loading *SignalT[bool]) {
+	suspense.readers[loading]++
+	if suspense.readers[loading] == 1 {
+		suspense.pending.Update(func(pending []*SignalT[bool]) []*SignalT[bool] {
+			return append(pending, loading)
+		})
+	}
+	OnCleanup(func() {
+		suspense.readers[loading]--
+		if suspense.readers[loading] > 0 {
+			return
+		}
+		delete(suspense.readers, loading)
+		suspense.pending.Update(func(pending []*SignalT[bool]) []*SignalT[bool] {
+			return removeOrderedItem(slices.Clone(pending), loading)
+		})
+	})
+}
+
+func suspenseOf(effect *Effect) *suspense {
+	suspense, _ := lookupScope(effect, suspenseKey{}).(*suspense)
+	return suspense
+}
+
+// Suspense shows fallback while any resource read with Get inside child
+// is loading. child stays alive meanwhile, so its effects keep running.
+func Suspense(fallback INode, child func() INode) INode {
+	state := &suspense{
+		pending: Signal([]*SignalT[bool]{}),
+		readers: map[*SignalT[bool]]int{},
+	}
+	var content INode
+	runInScope(suspenseKey{}, state, func() {
+		content = child()
+	})
+	loading := Computed(func() bool {
+		for _, pending := range state.pending.Get() {
+			if pending.Get() {
+				return true
+			}
+		}
+		return false
+	})
+	return Show(loading, fallback, content)
+}
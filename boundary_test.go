@@ -0,0 +1,97 @@
+//go:build !(js && wasm)
+
+package hx
+
+import (
+	"context"
+	"testing"
+)
+
+func TestErrorBoundaryCatchesRenderPanic(t *testing.T) {
+	boundary := ErrorBoundary(func(err error, reset func()) INode {
+		return P().Text(err.Error())
+	}, func() INode {
+		panic("broken")
+	})
+
+	if got, want := renderString(boundary), "<!--[--><P>panic: broken</P><!--]-->"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestErrorBoundaryResetsAfterEffectPanic(t *testing.T) {
+	broken := Signal(false)
+	var reset func()
+	boundary := ErrorBoundary(func(err error, retry func()) INode {
+		reset = retry
+		return P().Text("failed")
+	}, func() INode {
+		return P().BindText(Computed(func() string {
+			if broken.Get() {
+				panic("broken")
+			}
+			return "ok"
+		}))
+	})
+
+	broken.Set(true)
+	if got, want := renderString(boundary), "<!--[--><P>failed</P><!--]-->"; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	broken.Set(false)
+	reset()
+	if got, want := renderString(boundary), "<!--[--><P>ok</P><!--]-->"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestSuspenseShowsFallbackWhileLoading(t *testing.T) {
+	release := make(chan struct{})
+	resource := Resource[int, string](Signal(1), func(ctx context.Context, key int) (string, error) {
+		<-release
+		return "content", nil
+	})
+	defer resource.Dispose()
+	node := Suspense(P().Text("loading"), func() INode {
+		return P().BindText(Computed(resource.Get))
+	})
+
+	if got, want := renderString(node), "<!--[--><P>loading</P><!--]-->"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	close(release)
+	waitLoading(t, resource)
+
+	if got, want := renderString(node), "<!--[--><P>content</P><!--]-->"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestSuspenseReleasesDisposedReaders(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	resource := Resource[int, string](Signal(1), func(ctx context.Context, key int) (string, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return "content", nil
+	})
+	defer resource.Dispose()
+	reading := Signal(true)
+	node := Suspense(P().Text("loading"), func() INode {
+		return ShowFunc(reading, func() INode {
+			return P().BindText(Computed(resource.Get))
+		}, func() INode {
+			return P().Text("idle")
+		})
+	})
+
+	reading.Set(false)
+
+	if got, want := renderString(node), "<!--[--><!--[--><P>idle</P><!--]--><!--]-->"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
@@ -1,7 +1,10 @@
+//go:build js && wasm
+
 package hx
 
 import (
 	"log"
+	"slices"
 	"strings"
 	"syscall/js"
 
@@ -9,10 +12,11 @@ import (
 )
 
 type DiffRenderer struct {
-	mountpoint  dom.Element
-	markNodes   map[*VNode]struct{}
-	scheduled   bool
-	rafCallback js.Func
+	mountpoint    dom.Element
+	markNodes     map[*VNode]struct{}
+	pendingMounts []*VNode
+	scheduled     bool
+	rafCallback   js.Func
 }
 
 func newRenderer(element dom.Element) *DiffRenderer {
@@ -26,13 +30,26 @@ func newRenderer(element dom.Element) *DiffRenderer {
 }
 
 func (r *DiffRenderer) Mark(element *VNode) {
-	r.markNodes[element] = struct{}{}
+	if r.reachable(element) {
+		r.markNodes[element] = struct{}{}
+	}
+}
+
+// reachable tells if element hangs from the mount point. Nodes kept alive
+// while detached keep their renderer, but must not be synced until they
+// are attached again, which marks them once more.
+func (r *DiffRenderer) reachable(element *VNode) bool {
+	top := element
+	for top.father != nil {
+		top = top.father
+	}
+	return top.haveDomElement && top.domElement != nil && top.domElement.Underlying().Equal(r.mountpoint.Underlying())
 }
 
 func (r *DiffRenderer) createRaf() {
 	r.rafCallback = js.FuncOf(func(this js.Value, args []js.Value) any {
-		r.render()
 		r.scheduled = false
+		r.render()
 		return nil
 	})
 }
@@ -44,7 +61,12 @@ func (r *DiffRenderer) ScheduleRender() {
 	}
 }
 
-func (renderer DiffRenderer) render() {
+func (renderer *DiffRenderer) render() {
+	for node := range renderer.markNodes {
+		if !renderer.reachable(node) {
+			delete(renderer.markNodes, node)
+		}
+	}
 	rootLCA := renderer.GetMarkedCommonAncestor()
 	if rootLCA != nil {
 		renderer.syncNodes(rootLCA)
@@ -53,6 +75,34 @@ func (renderer DiffRenderer) render() {
 		node.render()
 		delete(renderer.markNodes, node)
 	}
+	renderer.flushMounts()
+}
+
+func (renderer *DiffRenderer) flushMounts() {
+	mounts := renderer.pendingMounts
+	renderer.pendingMounts = nil
+	Batch(func() {
+		for _, node := range mounts {
+			for _, hook := range node.mountHooks {
+				hook(node.domElement)
+			}
+		}
+	})
+}
+
+func (element *VNode) unmount() {
+	for _, child := range element.children {
+		if child != nil {
+			child.unmount()
+		}
+	}
+	if !element.mounted {
+		return
+	}
+	element.mounted = false
+	for _, hook := range element.unmountHooks {
+		hook()
+	}
 }
 
 func (renderer DiffRenderer) GetMarkedCommonAncestor() *VNode {
@@ -100,15 +150,8 @@ func (renderer DiffRenderer) generateCommonPathToRoot(currentPath []*VNode, mark
 
 func (renderer *DiffRenderer) syncNoopNode(element *VNode) bool {
 	if element.status == changeDeleted {
-		parent := element.father
-		if parent != nil {
-			for _, child := range element.children {
-				if child.haveDomElement {
-					parent.domElement.RemoveChild(child.domElement)
-				}
-			}
-			delete(renderer.markNodes, element)
-			element.father = nil
+		if element.father != nil {
+			renderer.detach(element)
 		}
 		return false
 	}
@@ -127,10 +170,10 @@ func (renderer *DiffRenderer) syncNoopNode(element *VNode) bool {
 
 	if element.status == changeNew {
 		element.status = unchanged
-		parent := element.father
-		if parent != nil && parent.haveDomElement {
-			for _, child := range element.children {
-				parent.domElement.AppendChild(child.domElement)
+		if parent := element.domParent(); parent != nil {
+			next := element.nextDomSibling()
+			for _, node := range element.domNodes() {
+				parent.domElement.InsertBefore(node, next)
 			}
 		}
 	}
@@ -138,35 +181,117 @@ func (renderer *DiffRenderer) syncNoopNode(element *VNode) bool {
 	return true
 }
 
+func (renderer *DiffRenderer) syncReactiveNode(element *VNode) bool {
+	if element.status == changeDeleted {
+		return renderer.syncNoopNode(element)
+	}
+
+	parent := element.domParent()
+	childs := make([]*VNode, 0, len(element.children))
+	for _, child := range element.children {
+		if child == nil {
+			continue
+		}
+		if child.status == changeDeleted {
+			renderer.detach(child)
+			continue
+		}
+		renderer.syncNodes(child)
+		childs = append(childs, child)
+	}
+	element.children = childs
+	element.status = unchanged
+
+	if parent != nil {
+		renderer.placeChildren(element, parent, childs)
+	}
+	element.placed = childs
+
+	return true
+}
+
+func (renderer *DiffRenderer) detach(element *VNode) {
+	element.unmount()
+	for _, node := range element.domNodes() {
+		node.Underlying().Call("remove")
+	}
+	element.releaseListeners()
+	element.disposeEffects()
+	delete(renderer.markNodes, element)
+	element.father = nil
+}
+
+func (renderer *DiffRenderer) placeChildren(element *VNode, parent *VNode, childs []*VNode) {
+	previous := make(map[*VNode]int, len(element.placed))
+	for index, child := range element.placed {
+		previous[child] = index
+	}
+	positions := make([]int, len(childs))
+	for index, child := range childs {
+		position, ok := previous[child]
+		if !ok {
+			position = -1
+		}
+		positions[index] = position
+	}
+	stable := longestIncreasingSubsequence(positions)
+
+	next := element.nextDomSibling()
+	for index := len(childs) - 1; index >= 0; index-- {
+		nodes := childs[index].domNodes()
+		if !stable[index] {
+			for _, node := range nodes {
+				parent.domElement.InsertBefore(node, next)
+			}
+		}
+		if len(nodes) > 0 {
+			next = nodes[0]
+		}
+	}
+}
+
 func (renderer *DiffRenderer) syncNodes(element *VNode) bool {
 	if element.tag == noopIdNode {
+		if element.reactive {
+			return renderer.syncReactiveNode(element)
+		}
 		return renderer.syncNoopNode(element)
 	}
 
 	if element.status == changeDeleted {
-		parent := element.father
-		if parent != nil {
-			if element.haveDomElement && parent.haveDomElement {
-				parent.domElement.RemoveChild(element.domElement)
-			}
-			delete(renderer.markNodes, element)
-			element.father = nil
+		if element.father != nil {
+			renderer.detach(element)
 		}
 		return false
 	}
 	if element.status == changeNew {
-		if !element.haveDomElement && len(element.tag) != 0 {
-			domNode := dom.GetWindow().Document().CreateElement(element.tag)
+		if !element.haveDomElement && element.tag == textIdNode {
+			element.domText = dom.GetWindow().Document().CreateTextNode(element.text.Value())
+			element.text.tick()
+			element.haveDomElement = true
+		} else if !element.haveDomElement && len(element.tag) != 0 {
+			document := dom.GetWindow().Document()
+			var domNode dom.Element
+			if len(element.namespace) > 0 {
+				domNode = document.CreateElementNS(element.namespace, element.tag)
+			} else {
+				domNode = document.CreateElement(element.tag)
+			}
 			element.domElement = domNode
 			element.haveDomElement = true
 		}
 		element.status = unchanged
 
-		parent := element.father
-		if parent != nil && parent.haveDomElement {
-			parent.domElement.AppendChild(element.domElement)
+		if !element.placedByFather() {
+			if parent := element.domParent(); parent != nil {
+				parent.domElement.InsertBefore(element.domNode(), element.nextDomSibling())
+			}
 		}
 	}
+	if !element.mounted && element.haveDomElement {
+		element.mounted = true
+		renderer.pendingMounts = append(renderer.pendingMounts, element)
+	}
 
 	childs := make([]*VNode, 0, len(element.children))
 	for _, child := range element.children {
@@ -183,7 +308,100 @@ func (renderer *DiffRenderer) syncNodes(element *VNode) bool {
 	return true
 }
 
+func (element *VNode) domParent() *VNode {
+	parent := element.father
+	for parent != nil && parent.tag == noopIdNode {
+		parent = parent.father
+	}
+	if parent == nil || !parent.haveDomElement {
+		return nil
+	}
+	return parent
+}
+
+func (element *VNode) placedByFather() bool {
+	father := element.father
+	return father != nil && father.tag == noopIdNode && (father.reactive || father.status == changeNew)
+}
+
+func (element *VNode) domNodes() []dom.Node {
+	if element.tag != noopIdNode {
+		if element.haveDomElement {
+			return []dom.Node{element.domNode()}
+		}
+		return nil
+	}
+	nodes := []dom.Node{}
+	for _, child := range element.children {
+		if child != nil && child.status != changeDeleted {
+			nodes = append(nodes, child.domNodes()...)
+		}
+	}
+	return nodes
+}
+
+func (element *VNode) nextDomSibling() dom.Node {
+	current := element
+	for current.father != nil {
+		parent := current.father
+		found := false
+		for _, sibling := range parent.children {
+			if sibling == current {
+				found = true
+				continue
+			}
+			if !found || sibling == nil {
+				continue
+			}
+			if node := sibling.firstPlacedDomNode(); node != nil {
+				return node
+			}
+		}
+		if parent.tag != noopIdNode {
+			return nil
+		}
+		current = parent
+	}
+	return nil
+}
+
+func (element *VNode) firstPlacedDomNode() dom.Node {
+	if element.status != unchanged {
+		return nil
+	}
+	if element.tag != noopIdNode {
+		if element.haveDomElement {
+			return element.domNode()
+		}
+		return nil
+	}
+	for _, child := range element.children {
+		if child == nil {
+			continue
+		}
+		if node := child.firstPlacedDomNode(); node != nil {
+			return node
+		}
+	}
+	return nil
+}
+
+func (element *VNode) domNode() dom.Node {
+	if element.tag == textIdNode {
+		return element.domText
+	}
+	return element.domElement
+}
+
 func (element *VNode) render() {
+	if element.tag == textIdNode {
+		if element.haveDomElement && element.text.status != unchanged {
+			element.domText.SetNodeValue(element.text.Value())
+			element.text.tick()
+		}
+		element.clearDirty()
+		return
+	}
 	if element.domElement == nil {
 		return
 	}
@@ -206,6 +424,9 @@ func (element *VNode) render() {
 	if element.isDirty(flagStyles) {
 		element.updateStyles()
 	}
+	if element.isDirty(flagProperties) {
+		element.updateProperties()
+	}
 	if element.id.status != unchanged {
 		element.updateId()
 	}
@@ -217,33 +438,55 @@ func (element *VNode) render() {
 	element.clearDirty()
 }
 
+func (element *VNode) updateProperties() {
+	jsVal := element.domElement.Underlying()
+	for key, property := range element.properties {
+		if property.status == unchanged {
+			continue
+		}
+		jsVal.Set(key, property.Value())
+		property.tick()
+		element.properties[key] = property
+	}
+}
+
 func (element *VNode) updateId() {
 	element.domElement.SetID(element.id.Value())
 	element.id.status = unchanged
 }
 
 func (element *VNode) updateAttributes() {
-	for attribute, value := range element.attributes {
+	for _, attribute := range slices.Clone(element.attributeOrder) {
+		value := element.attributes[attribute]
 		switch value.status {
 		case changeModified, changeNew:
 			element.domElement.SetAttribute(attribute, value.Value())
 		case changeDeleted:
 			element.domElement.RemoveAttribute(attribute)
+			delete(element.attributes, attribute)
+			element.attributeOrder = removeOrderedItem(element.attributeOrder, attribute)
+			continue
 		}
 		value.tick()
+		element.attributes[attribute] = value
 	}
 }
 
 func (element *VNode) updateStyles() {
 	stylesString := strings.Builder{}
-	for style, value := range element.styles {
-		if value.status != changeDeleted {
-			stylesString.WriteString(style)
-			stylesString.WriteString(":")
-			stylesString.WriteString(value.Value())
-			stylesString.WriteString("; ")
+	for _, style := range slices.Clone(element.styleOrder) {
+		value := element.styles[style]
+		if value.status == changeDeleted {
+			delete(element.styles, style)
+			element.styleOrder = removeOrderedItem(element.styleOrder, style)
+			continue
 		}
+		stylesString.WriteString(style)
+		stylesString.WriteString(":")
+		stylesString.WriteString(value.Value())
+		stylesString.WriteString("; ")
 		value.tick()
+		element.styles[style] = value
 	}
 	if stylesString.Len() > 0 {
 		element.domElement.SetAttribute("style", stylesString.String())
@@ -253,7 +496,8 @@ func (element *VNode) updateStyles() {
 }
 
 func (element *VNode) updateClasses() {
-	for class, status := range element.classes {
+	for _, class := range slices.Clone(element.classOrder) {
+		status := element.classes[class]
 		if strings.Contains(class, " ") {
 			log.Printf("Warning: ignoring class with spaces: %s", class)
 			continue
@@ -262,6 +506,7 @@ func (element *VNode) updateClasses() {
 		case changeDeleted:
 			element.domElement.Class().Remove(class)
 			delete(element.classes, class)
+			element.classOrder = removeOrderedItem(element.classOrder, class)
 		case changeNew:
 			element.domElement.Class().Add(class)
 			element.classes[class] = unchanged
@@ -274,14 +519,90 @@ func (element *VNode) updateClasses() {
 func (element *VNode) updateEventListeners() {
 	for event, listener := range element.eventListeners {
 		switch listener.status {
-		case changeNew:
-			currentListener := listener
-			element.domElement.AddEventListener(string(event), false, func(e dom.Event) {
-				currentListener.value(EventContext{
-					Target: element.Owner,
-					Event:  e,
-				})
-			})
+		case changeNew, changeModified:
+			options := listener.value.options
+			attached, ok := element.listenerFuncs[event]
+			if ok && (attached.capture != options.capture || attached.passive != options.passive) {
+				element.removeListener(event)
+				ok = false
+			}
+			if !ok {
+				element.attachListener(event, options)
+			}
+			listener.status = unchanged
+			element.eventListeners[event] = listener
+		case changeDeleted:
+			element.removeListener(event)
+			delete(element.eventListeners, event)
+		}
+	}
+}
+
+func (element *VNode) attachListener(event Event, options eventOptions) {
+	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
+		element.dispatch(event, dom.WrapEvent(args[0]))
+		return nil
+	})
+	element.domElement.Underlying().Call("addEventListener", string(event), fn, map[string]any{
+		"capture": options.capture,
+		"passive": options.passive,
+	})
+	element.listenerFuncs[event] = attachedListener{
+		fn:      fn,
+		capture: options.capture,
+		passive: options.passive,
+	}
+}
+
+func (element *VNode) dispatch(event Event, e dom.Event) {
+	listener, ok := element.eventListeners[event]
+	if !ok || listener.status == changeDeleted {
+		return
+	}
+	options := listener.value.options
+	if options.self && !e.Target().Underlying().Equal(element.domElement.Underlying()) {
+		return
+	}
+	if len(options.keys) > 0 && !slices.Contains(options.keys, e.Underlying().Get("key").String()) {
+		return
+	}
+	if options.preventDefault {
+		e.PreventDefault()
+	}
+	if options.stopPropagation {
+		e.StopPropagation()
+	}
+	if options.once {
+		element.removeListener(event)
+		delete(element.eventListeners, event)
+	}
+	listener.value.handler(EventContext{
+		Target: element.Owner,
+		Event:  e,
+	})
+}
+
+func (element *VNode) removeListener(event Event) {
+	attached, ok := element.listenerFuncs[event]
+	if !ok {
+		return
+	}
+	element.domElement.Underlying().Call("removeEventListener", string(event), attached.fn, attached.capture)
+	attached.fn.Release()
+	delete(element.listenerFuncs, event)
+}
+
+func (element *VNode) releaseListeners() {
+	for event := range element.listenerFuncs {
+		element.removeListener(event)
+		listener := element.eventListeners[event]
+		listener.status = changeNew
+		element.eventListeners[event] = listener
+		element.dirtyFlags[flagEventListeners] = true
+	}
+	for _, child := range element.children {
+		if child != nil {
+			child.releaseListeners()
 		}
 	}
 }
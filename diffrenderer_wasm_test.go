@@ -0,0 +1,234 @@
+//go:build js && wasm
+
+package hx
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+func TestInsertInTheMiddleKeepsOrder(t *testing.T) {
+	root, renderer, mount := newTestRoot()
+	a, c := P().Text("a"), P().Text("c")
+	root.Body(a, c)
+	renderer.render()
+
+	root.Body(a, P().Text("b"), c)
+	renderer.render()
+
+	if got, want := innerDOM(mount), "<P>a</P><P>b</P><P>c</P>"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestInsertInsideNestedNoopKeepsOrder(t *testing.T) {
+	root, renderer, mount := newTestRoot()
+	inner := Noop()
+	outer := Noop()
+	outer.Body(Span().Text("1"), inner)
+	root.Body(P().Text("before"), outer, P().Text("after"))
+	renderer.render()
+
+	inner.Body(Span().Text("2"), Span().Text("3"))
+	renderer.render()
+
+	want := "<P>before</P><SPAN>1</SPAN><SPAN>2</SPAN><SPAN>3</SPAN><P>after</P>"
+	if got := innerDOM(mount); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestShowInsertsBeforeNextSibling(t *testing.T) {
+	root, renderer, mount := newTestRoot()
+	visible := Signal(false)
+	root.Body(P().Text("before"), If(visible, Noop().Body(Span().Text("1"), Span().Text("2"))), P().Text("after"))
+	renderer.render()
+
+	visible.Set(true)
+	renderer.render()
+
+	want := "<P>before</P><SPAN>1</SPAN><SPAN>2</SPAN><P>after</P>"
+	if got := innerDOM(mount); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestEachKeyedMovesRowsToMatchOrder(t *testing.T) {
+	root, renderer, mount := newTestRoot()
+	items := Signal([]string{"a", "b", "c", "d"})
+	root.Body(
+		P().Text("before"),
+		EachKeyed(items, func(item string) string { return item }, func(index Gettable[int], item Gettable[string]) INode {
+			return Li().BindText(item)
+		}),
+		P().Text("after"),
+	)
+	renderer.render()
+
+	for _, order := range [][]string{
+		{"a", "x", "b", "c", "d"},
+		{"d", "c", "b", "a"},
+		{"b", "d"},
+		{"e", "b", "f", "d", "g"},
+	} {
+		items.Set(order)
+		renderer.render()
+
+		want := "<P>before</P>"
+		for _, item := range order {
+			want += "<LI>" + item + "</LI>"
+		}
+		want += "<P>after</P>"
+		if got := innerDOM(mount); got != want {
+			t.Errorf("order %v: expected %s, got %s", order, want, got)
+		}
+	}
+}
+
+func TestEachKeyedReusesDomNodes(t *testing.T) {
+	root, renderer, _ := newTestRoot()
+	items := Signal([]string{"a", "b", "c"})
+	rows := map[string]*VNode{}
+	root.Body(EachKeyed(items, func(item string) string { return item }, func(index Gettable[int], item Gettable[string]) INode {
+		row := Li().BindText(item)
+		rows[UntrackGet(item)] = row.AsVNode()
+		return row
+	}))
+	renderer.render()
+	before := map[string]js.Value{}
+	for key, row := range rows {
+		before[key] = row.domElement.Underlying()
+	}
+
+	items.Set([]string{"c", "a", "b"})
+	renderer.render()
+
+	for key, row := range rows {
+		if !row.domElement.Underlying().Equal(before[key]) {
+			t.Errorf("row %s was recreated", key)
+		}
+	}
+}
+
+func TestHiddenBranchDoesNotHijackRender(t *testing.T) {
+	root, renderer, mount := newTestRoot()
+	visible := Signal(true)
+	label := Signal("a")
+	items := Signal([]string{"1"})
+	root.Body(
+		If(visible, P().BindText(label)),
+		Each(items, func(index int, item string) INode { return Li().Text(item) }),
+	)
+	renderer.render()
+
+	visible.Set(false)
+	renderer.render()
+
+	label.Set("b")
+	items.Set([]string{"1", "2"})
+	renderer.render()
+
+	if got, want := innerDOM(mount), "<LI>1</LI><LI>2</LI>"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	visible.Set(true)
+	renderer.render()
+
+	if got, want := innerDOM(mount), "<P>b</P><LI>1</LI><LI>2</LI>"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestUncomparablePropertyReachesTheDom(t *testing.T) {
+	root, renderer, _ := newTestRoot()
+	div := Div().Property("x", []any{"a"})
+	root.Body(div)
+	renderer.render()
+
+	if got := div.AsVNode().domElement.Underlying().Get("x").Index(0).String(); got != "a" {
+		t.Errorf("expected property to be set, got %q", got)
+	}
+}
+
+func TestBindSelectedSelectsOptionsLoadedLater(t *testing.T) {
+	root, renderer, _ := newTestRoot()
+	opts := Signal([]string{"a"})
+	options := map[string]*OptionNode{}
+	root.Body(Select().BindSelected(Signal("b")).Body(
+		Each(opts, func(index int, value string) INode {
+			options[value] = Option().Value(value)
+			return options[value]
+		}),
+	))
+	renderer.render()
+
+	opts.Set([]string{"a", "b"})
+	renderer.render()
+
+	if !options["b"].domElement.Underlying().Get("selected").Truthy() {
+		t.Error("expected option b to be selected in the DOM")
+	}
+}
+
+func TestMountHooksFollowIfToggles(t *testing.T) {
+	root, renderer, _ := newTestRoot()
+	visible := Signal(true)
+	mounts, unmounts := 0, 0
+	child := P()
+	child.Text("child")
+	child.OnMount(func(element nativeElement) { mounts++ })
+	child.OnUnmount(func() { unmounts++ })
+	root.Body(If(visible, child))
+	renderer.render()
+
+	visible.Set(false)
+	renderer.render()
+	visible.Set(true)
+	renderer.render()
+
+	if mounts != 2 || unmounts != 1 {
+		t.Errorf("expected 2 mounts and 1 unmount, got %d and %d", mounts, unmounts)
+	}
+}
+
+func TestBindTUpdatesTheTextNode(t *testing.T) {
+	root, renderer, mount := newTestRoot()
+	label := Signal("a")
+	text := BindT(label)
+	root.Body(P().Body(T("x "), text))
+	renderer.render()
+	node := text.domText.Underlying()
+
+	label.Set("b")
+	renderer.render()
+
+	if !text.domText.Underlying().Equal(node) {
+		t.Error("expected the text node to be reused")
+	}
+	if got := node.Get("nodeValue").String(); got != "b" {
+		t.Errorf("expected nodeValue b, got %q", got)
+	}
+	if got, want := innerDOM(mount), "<P>x b</P>"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestSvgChildrenAreCreatedInTheirNamespace(t *testing.T) {
+	root, renderer, mount := newTestRoot()
+	link, circle, div := A(), Circle(), Div()
+	root.Body(Svg().Body(link.Body(circle), ForeignObject().Body(div)))
+	renderer.render()
+
+	for _, node := range []*VNode{link.AsVNode(), circle} {
+		if got := node.domElement.Underlying().Get("namespaceURI").String(); got != NamespaceSVG {
+			t.Errorf("expected %s to be in the SVG namespace, got %s", node.tag, got)
+		}
+	}
+	if got := div.domElement.Underlying().Get("namespaceURI"); !got.IsNull() {
+		t.Errorf("expected div inside foreignObject to be HTML, got %v", got)
+	}
+	if got, want := innerDOM(mount), "<svg><a><circle></circle></a><foreignObject><DIV></DIV></foreignObject></svg>"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
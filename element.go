@@ -1,7 +1,8 @@
 package hx
 
 import (
-	"honnef.co/go/js/dom/v2"
+	"log"
+	"strings"
 )
 
 type Renderer interface {
@@ -9,20 +10,6 @@ type Renderer interface {
 	Mark(element *VNode)
 }
 
-type EventContext struct {
-	Target INode
-	Event  dom.Event
-}
-
-type Event string
-
-const (
-	EventClick  Event = "click"
-	EventInput  Event = "input"
-	EventChange Event = "change"
-	EventKeyUp  Event = "keyup"
-)
-
 type changeStatus int
 
 const (
@@ -57,13 +44,14 @@ type INode interface {
 	RemoveClass(classes ...string) INode
 	HaveClass(class string) bool
 
-	On(event Event, handler func(ctx EventContext)) INode
+	On(event Event, handler func(ctx EventContext), options ...EventOption) INode
+	Off(event Event) INode
 	OnClick(handler func(ctx EventContext)) INode
 }
 
 type dirtyFlag int
 
-const flagNumber = 5
+const flagNumber = 6
 
 const (
 	flagStyles dirtyFlag = iota
@@ -71,10 +59,12 @@ const (
 	flagAttributes
 	flagEventListeners
 	flagChildren
+	flagProperties
 )
 
 type VNode struct {
-	domElement     dom.Element
+	domElement     nativeElement
+	domText        nativeText
 	haveDomElement bool
 
 	father *VNode
@@ -89,37 +79,31 @@ type VNode struct {
 	text  diffValue[string]
 	value diffValue[string]
 
+	namespace string
+
 	styles         map[string]diffValue[string]
 	classes        map[string]changeStatus
 	attributes     map[string]diffValue[string]
-	eventListeners map[Event]singleValue[func(EventContext)]
+	properties     map[string]diffValue[any]
+	eventListeners map[Event]singleValue[eventListener]
+	listenerFuncs  map[Event]attachedListener
 	children       []*VNode
 
-	dirtyFlags [flagNumber]bool
-}
+	classOrder     []string
+	styleOrder     []string
+	attributeOrder []string
 
-func NewWithRenderer(element dom.Element, renderer Renderer) *VNode {
-	VNode := newVNode(element.NodeName())
-	VNode.status = unchanged
-	VNode.domElement = element
-	VNode.haveDomElement = true
-	VNode.renderer = renderer
-	VNode.haveRenderer = true
-	return VNode
-}
+	reactive   bool
+	placed     []*VNode
+	effects    []*Effect
+	persistent bool
+	kept       []*VNode
 
-func New(element dom.Element) *VNode {
-	return NewWithRenderer(element, newRenderer(element))
-}
-
-func NewFromId(id string) *VNode {
-	mountPoint := dom.GetWindow().Document().GetElementByID(id)
-	return New(mountPoint)
-}
+	mounted      bool
+	mountHooks   []func(element nativeElement)
+	unmountHooks []func()
 
-func NewFromIdWithRenderer(id string, renderer Renderer) *VNode {
-	mountPoint := dom.GetWindow().Document().GetElementByID(id)
-	return NewWithRenderer(mountPoint, renderer)
+	dirtyFlags [flagNumber]bool
 }
 
 func NewWithoutMount(tag string, renderer Renderer) *VNode {
@@ -141,7 +125,9 @@ func newVNode(tag string) *VNode {
 		styles:         map[string]diffValue[string]{},
 		classes:        map[string]changeStatus{},
 		attributes:     map[string]diffValue[string]{},
-		eventListeners: map[Event]singleValue[func(EventContext)]{},
+		properties:     map[string]diffValue[any]{},
+		eventListeners: map[Event]singleValue[eventListener]{},
+		listenerFuncs:  map[Event]attachedListener{},
 		children:       []*VNode{},
 
 		dirtyFlags: [flagNumber]bool{false},
@@ -150,8 +136,32 @@ func newVNode(tag string) *VNode {
 	return vnode
 }
 
-func (element *VNode) Underlying() dom.Element {
-	return element.domElement
+func newNSVNode(namespace, tag string) *VNode {
+	vnode := newVNode(tag)
+	vnode.namespace = namespace
+	return vnode
+}
+
+func (element *VNode) inheritNamespace(namespace string) {
+	if element.tag == textIdNode || len(element.namespace) > 0 {
+		return
+	}
+	if element.tag != noopIdNode {
+		element.namespace = namespace
+		element.tag = strings.ToLower(element.tag)
+	}
+	for _, child := range element.children {
+		if child != nil {
+			child.inheritNamespace(namespace)
+		}
+	}
+}
+
+func (element *VNode) childNamespace() string {
+	if element.tag == "foreignObject" {
+		return ""
+	}
+	return element.namespace
 }
 
 func (element *VNode) AsVNode() *VNode {
@@ -184,9 +194,41 @@ func (element *VNode) setRenderer(renderer Renderer, haveRenderer bool) {
 		element.children[index].setRenderer(renderer, haveRenderer)
 		element.children[index].haveRenderer = haveRenderer
 	}
+	if element.tag == "OPTION" {
+		element.adoptSelection()
+	}
 	element.setDirty(flagChildren)
 }
 
+func (element *VNode) ownEffect(fn func()) *Effect {
+	effect := EffectFunc(fn)
+	element.effects = append(element.effects, effect)
+	return effect
+}
+
+func (element *VNode) disposeEffects() {
+	if element.persistent {
+		return
+	}
+	element.releaseEffects()
+}
+
+func (element *VNode) releaseEffects() {
+	for _, effect := range element.effects {
+		effect.Dispose()
+	}
+	element.effects = nil
+	for _, child := range element.children {
+		if child != nil {
+			child.disposeEffects()
+		}
+	}
+	for _, kept := range element.kept {
+		kept.releaseEffects()
+	}
+	element.kept = nil
+}
+
 func (element *VNode) scheludeRender() {
 	if element.haveRenderer {
 		element.renderer.ScheduleRender()
@@ -198,12 +240,25 @@ func (element *VNode) Body(childs ...INode) INode {
 }
 
 func (element *VNode) BodyList(childs []INode) INode {
+	incoming := make(map[*VNode]struct{}, len(childs))
+	for _, child := range childs {
+		if child != nil {
+			incoming[asVNode(child)] = struct{}{}
+		}
+	}
+
+	remaining := make([]*VNode, 0, len(element.children)+len(childs))
 	for _, child := range element.children {
 		if child == nil {
 			continue
 		}
+		if _, ok := incoming[child]; ok {
+			continue
+		}
 		child.status = changeDeleted
+		remaining = append(remaining, child)
 	}
+	element.children = remaining
 
 	for _, child := range childs {
 		if child == nil {
@@ -211,8 +266,11 @@ func (element *VNode) BodyList(childs []INode) INode {
 		}
 		realNode := asVNode(child)
 		realNode.status = changeNew
-		realNode.setRenderer(element.renderer, element.haveRenderer)
+		if namespace := element.childNamespace(); len(namespace) > 0 {
+			realNode.inheritNamespace(namespace)
+		}
 		realNode.father = element
+		realNode.setRenderer(element.renderer, element.haveRenderer)
 		element.children = append(element.children, realNode)
 	}
 
@@ -222,25 +280,53 @@ func (element *VNode) BodyList(childs []INode) INode {
 	return element
 }
 
-func (element *VNode) On(event Event, handler func(ctx EventContext)) INode {
+func (element *VNode) On(event Event, handler func(ctx EventContext), options ...EventOption) INode {
+	listener := element.eventListeners[event]
+	status := changeModified
+	if _, attached := element.listenerFuncs[event]; !attached {
+		status = changeNew
+	}
+	boundary := currentBoundary()
+	listener.assign(eventListener{
+		handler: func(ctx EventContext) {
+			boundary.catch(func() {
+				Batch(func() {
+					Untrack(func() {
+						handler(ctx)
+					})
+				})
+			})
+			element.scheludeRender()
+		},
+		options: newEventOptions(options),
+	}, status)
+	element.eventListeners[event] = listener
+
+	element.setDirty(flagEventListeners)
+	return element
+}
+
+func (element *VNode) Off(event Event) INode {
 	listener, ok := element.eventListeners[event]
 	if !ok {
-		element.eventListeners[event] = singleValue[func(EventContext)]{
-			value: func(ctx EventContext) {
-				Untrack(func() {
-					handler(ctx)
-				})
-				element.scheludeRender()
-			},
-			status: changeNew,
-		}
+		return element
 	}
-	listener.assign(handler, changeModified)
-
+	listener.status = changeDeleted
+	element.eventListeners[event] = listener
 	element.setDirty(flagEventListeners)
 	return element
 }
 
+func (element *VNode) OnMount(hook func(element nativeElement)) INode {
+	element.mountHooks = append(element.mountHooks, hook)
+	return element
+}
+
+func (element *VNode) OnUnmount(hook func()) INode {
+	element.unmountHooks = append(element.unmountHooks, hook)
+	return element
+}
+
 func (element *VNode) OnClick(handler func(ctx EventContext)) INode {
 	return element.On(EventClick, handler)
 }
@@ -253,7 +339,7 @@ func (element *VNode) Text(t string) INode {
 }
 
 func (element *VNode) BindText(signal Gettable[string]) INode {
-	EffectFunc(func() {
+	element.ownEffect(func() {
 		element.Text(signal.Get())
 		element.scheludeRender()
 	})
@@ -265,8 +351,12 @@ func (element *VNode) Class(classes ...string) INode {
 		if len(create) <= 0 {
 			continue
 		}
-		_, ok := element.classes[create]
+		status, ok := element.classes[create]
 		if !ok {
+			element.classes[create] = changeNew
+			element.classOrder = append(element.classOrder, create)
+			element.setDirty(flagClasses)
+		} else if status == changeDeleted {
 			element.classes[create] = changeNew
 			element.setDirty(flagClasses)
 		}
@@ -299,7 +389,7 @@ func (element *VNode) Attribute(key, value string) INode {
 	}
 
 	oldValue, ok := element.attributes[key]
-	if ok && oldValue.equals(value) {
+	if ok && oldValue.status != changeDeleted && oldValue.equals(value) {
 		return element
 	}
 
@@ -308,6 +398,7 @@ func (element *VNode) Attribute(key, value string) INode {
 		status = changeModified
 	} else {
 		status = changeNew
+		element.attributeOrder = append(element.attributeOrder, key)
 	}
 	oldValue.assign(value, status)
 	element.attributes[key] = oldValue
@@ -345,7 +436,7 @@ func (element *VNode) HaveAttribute(key, value string) bool {
 
 func (element *VNode) Style(key, value string) INode {
 	oldValue, ok := element.styles[key]
-	if ok && oldValue.equals(value) {
+	if ok && oldValue.status != changeDeleted && oldValue.equals(value) {
 		return element
 	}
 
@@ -354,6 +445,7 @@ func (element *VNode) Style(key, value string) INode {
 		status = changeModified
 	} else {
 		status = changeNew
+		element.styleOrder = append(element.styleOrder, key)
 	}
 	oldValue.assign(value, status)
 	element.styles[key] = oldValue
@@ -382,7 +474,7 @@ func (element *VNode) GetStyle(key string) string {
 	if value.status == changeDeleted {
 		return ""
 	}
-	return value.value
+	return value.Value()
 }
 
 func (element *VNode) HaveStyle(key, value string) bool {
@@ -390,6 +482,40 @@ func (element *VNode) HaveStyle(key, value string) bool {
 	return ok && v.status != changeDeleted && v.Value() == value
 }
 
+func (element *VNode) Property(key string, value any) INode {
+	if !isPropertyValue(value) {
+		log.Printf("Warning: ignoring property %s: unsupported value of type %T", key, value)
+		return element
+	}
+	property := element.properties[key]
+	changed := true
+	if isComparable(value) {
+		changed = property.assign(value, changeModified)
+	} else {
+		property.nextValue = value
+		property.status = changeModified
+	}
+	element.properties[key] = property
+	if changed {
+		element.setDirty(flagProperties)
+	}
+	return element
+}
+
+func (element *VNode) syncProperty(key string, value any) {
+	property := element.properties[key]
+	property.sync(value)
+	element.properties[key] = property
+}
+
+func (element *VNode) GetProperty(key string) any {
+	property, ok := element.properties[key]
+	if !ok {
+		return nil
+	}
+	return property.Value()
+}
+
 func (element *VNode) Id(id string) INode {
 	if element.id.value == id {
 		return element
@@ -402,8 +528,7 @@ func (element *VNode) Id(id string) INode {
 
 func (element *InputVNode) BindOnChange(signal Settable[string]) *InputVNode {
 	element.On(EventChange, func(ctx EventContext) {
-		input := ctx.Event.Target().(*dom.HTMLInputElement)
-		signal.Set(input.Value())
+		signal.Set(inputValue(ctx))
 	})
 	return element
 }
@@ -420,10 +545,11 @@ type AVNode struct {
 }
 
 func newA(element *VNode) *AVNode {
-	element.Owner = element
-	return &AVNode{
+	a := &AVNode{
 		*element,
 	}
+	a.Owner = a
+	return a
 }
 
 func (e *AVNode) Href(v string) *AVNode {
@@ -450,15 +576,14 @@ func (element *InputVNode) Value(t string) INode {
 
 func (element *InputVNode) BindOnInput(signal Settable[string]) *InputVNode {
 	element.On(EventInput, func(ctx EventContext) {
-		input := ctx.Event.Target().(*dom.HTMLInputElement)
-		signal.Set(input.Value())
+		signal.Set(inputValue(ctx))
 		element.scheludeRender()
 	})
 	return element
 }
 
 func (element *InputVNode) BindValue(signal Gettable[string]) *InputVNode {
-	EffectFunc(func() {
+	element.ownEffect(func() {
 		element.Value(signal.Get())
 		element.scheludeRender()
 	})
@@ -494,8 +619,16 @@ func (element *TextAreaNode) Value(t string) INode {
 	return element
 }
 
+func (element *TextAreaNode) BindOnInput(signal Settable[string]) *TextAreaNode {
+	element.On(EventInput, func(ctx EventContext) {
+		signal.Set(inputValue(ctx))
+		element.scheludeRender()
+	})
+	return element
+}
+
 func (element *TextAreaNode) BindValue(signal Gettable[string]) *TextAreaNode {
-	EffectFunc(func() {
+	element.ownEffect(func() {
 		v := signal.Get()
 		element.Value(v)
 		element.Text(v)
@@ -524,13 +657,37 @@ func (element *OptionNode) Value(t string) *OptionNode {
 }
 
 func (element *OptionNode) Selected() *OptionNode {
-	element.Attribute("selected", "selected")
-	element.mark()
+	element.Property("selected", true)
+	return element
+}
+
+type SelectNode struct {
+	VNode
+	selection map[string]bool
+}
+
+func asSelect(node *VNode) *SelectNode {
+	selectNode := &SelectNode{
+		VNode: *node,
+	}
+	selectNode.Owner = selectNode
+	return selectNode
+}
+
+func (element *SelectNode) Body(childs ...INode) INode {
+	return element.BodyList(childs)
+}
+
+func (element *SelectNode) BodyList(childs []INode) INode {
+	element.VNode.BodyList(childs)
+	element.applySelection()
 	return element
 }
 
 const noopIdNode string = "noop"
 
+const textIdNode string = "#text"
+
 type NoopNode struct {
 	VNode
 }
@@ -578,7 +735,10 @@ func (nop *NoopNode) Class(classes ...string) INode {
 func (nop *NoopNode) RemoveClass(classes ...string) INode {
 	return nop
 }
-func (nop *NoopNode) On(event Event, handler func(ctx EventContext)) INode {
+func (nop *NoopNode) On(event Event, handler func(ctx EventContext), options ...EventOption) INode {
+	return nop
+}
+func (nop *NoopNode) Off(event Event) INode {
 	return nop
 }
 func (nop *NoopNode) OnClick(handler func(ctx EventContext)) INode {
@@ -613,7 +773,7 @@ func Img() *VNode             { return newVNode("IMG") }
 func Input() *InputVNode      { return asInput(newVNode("INPUT")) }
 func Label() *VNode           { return newVNode("LABEL") }
 func Form() *VNode            { return newVNode("FORM") }
-func Select() *VNode          { return newVNode("SELECT") }
+func Select() *SelectNode     { return asSelect(newVNode("SELECT")) }
 func Option() *OptionNode     { return asOption(newVNode("OPTION")) }
 func TextArea() *TextAreaNode { return asTextArea(newVNode("TEXTAREA")) }
 
@@ -642,8 +802,6 @@ func Audio() *VNode  { return newVNode("AUDIO") }
 func Source() *VNode { return newVNode("SOURCE") }
 
 func Canvas() *VNode { return newVNode("CANVAS") }
-func Svg() *VNode    { return newVNode("SVG") }
-func Path() *VNode   { return newVNode("PATH") }
 
 func Br() *VNode { return newVNode("BR") }
 func Hr() *VNode { return newVNode("HR") }
@@ -652,3 +810,15 @@ func Code() *VNode { return newVNode("CODE") }
 func Pre() *VNode  { return newVNode("PRE") }
 
 func Noop() *NoopNode { return asNoop(newVNode(noopIdNode)) }
+
+func T(text string) *VNode {
+	node := newVNode(textIdNode)
+	node.Text(text)
+	return node
+}
+
+func BindT(signal Gettable[string]) *VNode {
+	node := newVNode(textIdNode)
+	node.BindText(signal)
+	return node
+}
@@ -0,0 +1,22 @@
+//go:build !(js && wasm)
+
+package hx
+
+// Outside the browser there is no DOM: nodes are only rendered to strings
+// and event handlers never fire.
+
+type nativeElement = any
+
+type nativeText = any
+
+type nativeFunc struct{}
+
+type nativeEvent interface {
+	PreventDefault()
+	StopPropagation()
+	Type() string
+}
+
+func isNativeValue(value any) bool {
+	return false
+}
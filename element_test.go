@@ -0,0 +1,29 @@
+package hx
+
+import "testing"
+
+func TestPropertyAcceptsUncomparableValues(t *testing.T) {
+	element := Div()
+	element.Property("x", []any{"a"})
+	element.Property("x", []any{"b"})
+
+	got, ok := element.GetProperty("x").([]any)
+	if !ok || len(got) != 1 || got[0] != "b" {
+		t.Fatalf("expected [b], got %v", element.GetProperty("x"))
+	}
+	if !element.isDirty(flagProperties) {
+		t.Fatal("expected properties to be dirty")
+	}
+}
+
+func TestPropertyIgnoresUnsupportedValues(t *testing.T) {
+	element := Div()
+	element.Property("x", []string{"a"})
+
+	if got := element.GetProperty("x"); got != nil {
+		t.Errorf("expected unsupported value to be ignored, got %v", got)
+	}
+	if element.isDirty(flagProperties) {
+		t.Error("expected properties to stay clean")
+	}
+}
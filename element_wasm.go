@@ -0,0 +1,53 @@
+//go:build js && wasm
+
+package hx
+
+import (
+	"syscall/js"
+
+	"honnef.co/go/js/dom/v2"
+)
+
+type nativeElement = dom.Element
+
+type nativeText = *dom.Text
+
+type nativeEvent = dom.Event
+
+type nativeFunc = js.Func
+
+func NewWithRenderer(element dom.Element, renderer Renderer) *VNode {
+	VNode := newVNode(element.NodeName())
+	VNode.status = unchanged
+	VNode.domElement = element
+	VNode.haveDomElement = true
+	VNode.renderer = renderer
+	VNode.haveRenderer = true
+	return VNode
+}
+
+func New(element dom.Element) *VNode {
+	return NewWithRenderer(element, newRenderer(element))
+}
+
+func NewFromId(id string) *VNode {
+	mountPoint := dom.GetWindow().Document().GetElementByID(id)
+	return New(mountPoint)
+}
+
+func NewFromIdWithRenderer(id string, renderer Renderer) *VNode {
+	mountPoint := dom.GetWindow().Document().GetElementByID(id)
+	return NewWithRenderer(mountPoint, renderer)
+}
+
+func (element *VNode) Underlying() dom.Element {
+	return element.domElement
+}
+
+func isNativeValue(value any) bool {
+	switch value.(type) {
+	case js.Value, js.Func:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package hx
+
+import "net/url"
+
+type EventContext struct {
+	Target INode
+	Event  nativeEvent
+}
+
+type Event string
+
+const (
+	EventClick       Event = "click"
+	EventDoubleClick Event = "dblclick"
+	EventContextMenu Event = "contextmenu"
+	EventInput       Event = "input"
+	EventChange      Event = "change"
+	EventSubmit      Event = "submit"
+	EventReset       Event = "reset"
+	EventInvalid     Event = "invalid"
+	EventSelect      Event = "select"
+
+	EventKeyDown  Event = "keydown"
+	EventKeyUp    Event = "keyup"
+	EventKeyPress Event = "keypress"
+
+	EventFocus    Event = "focus"
+	EventBlur     Event = "blur"
+	EventFocusIn  Event = "focusin"
+	EventFocusOut Event = "focusout"
+
+	EventMouseDown  Event = "mousedown"
+	EventMouseUp    Event = "mouseup"
+	EventMouseMove  Event = "mousemove"
+	EventMouseEnter Event = "mouseenter"
+	EventMouseLeave Event = "mouseleave"
+	EventMouseOver  Event = "mouseover"
+	EventMouseOut   Event = "mouseout"
+	EventWheel      Event = "wheel"
+
+	EventPointerDown   Event = "pointerdown"
+	EventPointerUp     Event = "pointerup"
+	EventPointerMove   Event = "pointermove"
+	EventPointerEnter  Event = "pointerenter"
+	EventPointerLeave  Event = "pointerleave"
+	EventPointerCancel Event = "pointercancel"
+
+	EventTouchStart  Event = "touchstart"
+	EventTouchMove   Event = "touchmove"
+	EventTouchEnd    Event = "touchend"
+	EventTouchCancel Event = "touchcancel"
+
+	EventDrag      Event = "drag"
+	EventDragStart Event = "dragstart"
+	EventDragEnd   Event = "dragend"
+	EventDragEnter Event = "dragenter"
+	EventDragLeave Event = "dragleave"
+	EventDragOver  Event = "dragover"
+	EventDrop      Event = "drop"
+
+	EventCopy  Event = "copy"
+	EventCut   Event = "cut"
+	EventPaste Event = "paste"
+
+	EventScroll Event = "scroll"
+	EventLoad   Event = "load"
+	EventError  Event = "error"
+
+	EventAnimationStart  Event = "animationstart"
+	EventAnimationEnd    Event = "animationend"
+	EventTransitionEnd   Event = "transitionend"
+	EventTransitionStart Event = "transitionstart"
+)
+
+const (
+	KeyEnter  = "Enter"
+	KeyEscape = "Escape"
+	KeyTab    = "Tab"
+	KeySpace  = " "
+)
+
+type Modifiers struct {
+	Alt   bool
+	Ctrl  bool
+	Meta  bool
+	Shift bool
+}
+
+type KeyboardEventContext struct {
+	EventContext
+	Key       string
+	Code      string
+	Repeat    bool
+	Modifiers Modifiers
+}
+
+type MouseEventContext struct {
+	EventContext
+	ClientX   float64
+	ClientY   float64
+	OffsetX   float64
+	OffsetY   float64
+	PageX     float64
+	PageY     float64
+	Button    int
+	Buttons   int
+	Modifiers Modifiers
+}
+
+type File struct {
+	Name   string
+	Type   string
+	Size   int
+	native nativeValue
+}
+
+type SubmitEventContext struct {
+	EventContext
+	Values url.Values
+	Files  map[string][]File
+}
+
+type DropEventContext struct {
+	MouseEventContext
+	Text  string
+	Files []File
+}
+
+type eventOptions struct {
+	capture bool
+	passive bool
+	once    bool
+
+	preventDefault  bool
+	stopPropagation bool
+	self            bool
+	keys            []string
+}
+
+type EventOption func(options *eventOptions)
+
+func newEventOptions(options []EventOption) eventOptions {
+	result := eventOptions{}
+	for _, option := range options {
+		option(&result)
+	}
+	return result
+}
+
+func Capture() EventOption {
+	return func(options *eventOptions) { options.capture = true }
+}
+
+func Passive() EventOption {
+	return func(options *eventOptions) { options.passive = true }
+}
+
+func Once() EventOption {
+	return func(options *eventOptions) { options.once = true }
+}
+
+func PreventDefault() EventOption {
+	return func(options *eventOptions) { options.preventDefault = true }
+}
+
+func StopPropagation() EventOption {
+	return func(options *eventOptions) { options.stopPropagation = true }
+}
+
+func Self() EventOption {
+	return func(options *eventOptions) { options.self = true }
+}
+
+func Keys(keys ...string) EventOption {
+	return func(options *eventOptions) { options.keys = append(options.keys, keys...) }
+}
+
+type eventListener struct {
+	handler func(EventContext)
+	options eventOptions
+}
+
+type attachedListener struct {
+	fn      nativeFunc
+	capture bool
+	passive bool
+}
+
+func (element *VNode) OnKeyboard(event Event, handler func(ctx KeyboardEventContext), options ...EventOption) INode {
+	return element.On(event, func(ctx EventContext) {
+		handler(keyboardEventContext(ctx))
+	}, options...)
+}
+
+func (element *VNode) OnMouse(event Event, handler func(ctx MouseEventContext), options ...EventOption) INode {
+	return element.On(event, func(ctx EventContext) {
+		handler(mouseEventContext(ctx))
+	}, options...)
+}
+
+func (element *VNode) OnKeyDown(handler func(ctx KeyboardEventContext), options ...EventOption) INode {
+	return element.OnKeyboard(EventKeyDown, handler, options...)
+}
+
+func (element *VNode) OnMouseDown(handler func(ctx MouseEventContext), options ...EventOption) INode {
+	return element.OnMouse(EventMouseDown, handler, options...)
+}
+
+func (element *VNode) OnMouseUp(handler func(ctx MouseEventContext), options ...EventOption) INode {
+	return element.OnMouse(EventMouseUp, handler, options...)
+}
+
+func (element *VNode) OnMouseMove(handler func(ctx MouseEventContext), options ...EventOption) INode {
+	return element.OnMouse(EventMouseMove, handler, options...)
+}
+
+// OnSubmit always prevents the default submission so the page is not reloaded.
+func (element *VNode) OnSubmit(handler func(ctx SubmitEventContext), options ...EventOption) INode {
+	options = append([]EventOption{PreventDefault()}, options...)
+	return element.On(EventSubmit, func(ctx EventContext) {
+		handler(submitEventContext(ctx))
+	}, options...)
+}
+
+// OnDrop also prevents the default dragover behaviour unless a dragover
+// handler is already set, otherwise the browser never fires drop.
+func (element *VNode) OnDrop(handler func(ctx DropEventContext), options ...EventOption) INode {
+	if _, ok := element.eventListeners[EventDragOver]; !ok {
+		element.On(EventDragOver, func(ctx EventContext) {}, PreventDefault())
+	}
+	options = append([]EventOption{PreventDefault()}, options...)
+	return element.On(EventDrop, func(ctx EventContext) {
+		handler(dropEventContext(ctx))
+	}, options...)
+}
@@ -0,0 +1,33 @@
+//go:build !(js && wasm)
+
+package hx
+
+type nativeValue struct{}
+
+func inputValue(ctx EventContext) string {
+	return ""
+}
+
+func inputChecked(ctx EventContext) bool {
+	return false
+}
+
+func selectedValues(ctx EventContext) []string {
+	return nil
+}
+
+func keyboardEventContext(ctx EventContext) KeyboardEventContext {
+	return KeyboardEventContext{EventContext: ctx}
+}
+
+func mouseEventContext(ctx EventContext) MouseEventContext {
+	return MouseEventContext{EventContext: ctx}
+}
+
+func submitEventContext(ctx EventContext) SubmitEventContext {
+	return SubmitEventContext{EventContext: ctx}
+}
+
+func dropEventContext(ctx EventContext) DropEventContext {
+	return DropEventContext{MouseEventContext: mouseEventContext(ctx)}
+}
@@ -0,0 +1,113 @@
+//go:build js && wasm
+
+package hx
+
+import (
+	"net/url"
+	"syscall/js"
+)
+
+type nativeValue = js.Value
+
+func (file File) Underlying() js.Value {
+	return file.native
+}
+
+func inputValue(ctx EventContext) string {
+	return ctx.Event.Underlying().Get("target").Get("value").String()
+}
+
+func inputChecked(ctx EventContext) bool {
+	return ctx.Event.Underlying().Get("target").Get("checked").Bool()
+}
+
+func selectedValues(ctx EventContext) []string {
+	options := ctx.Event.Underlying().Get("target").Get("selectedOptions")
+	values := make([]string, options.Length())
+	for i := range values {
+		values[i] = options.Index(i).Get("value").String()
+	}
+	return values
+}
+
+func modifiersOf(event js.Value) Modifiers {
+	return Modifiers{
+		Alt:   event.Get("altKey").Bool(),
+		Ctrl:  event.Get("ctrlKey").Bool(),
+		Meta:  event.Get("metaKey").Bool(),
+		Shift: event.Get("shiftKey").Bool(),
+	}
+}
+
+func keyboardEventContext(ctx EventContext) KeyboardEventContext {
+	event := ctx.Event.Underlying()
+	return KeyboardEventContext{
+		EventContext: ctx,
+		Key:          event.Get("key").String(),
+		Code:         event.Get("code").String(),
+		Repeat:       event.Get("repeat").Bool(),
+		Modifiers:    modifiersOf(event),
+	}
+}
+
+func mouseEventContext(ctx EventContext) MouseEventContext {
+	event := ctx.Event.Underlying()
+	return MouseEventContext{
+		EventContext: ctx,
+		ClientX:      event.Get("clientX").Float(),
+		ClientY:      event.Get("clientY").Float(),
+		OffsetX:      event.Get("offsetX").Float(),
+		OffsetY:      event.Get("offsetY").Float(),
+		PageX:        event.Get("pageX").Float(),
+		PageY:        event.Get("pageY").Float(),
+		Button:       event.Get("button").Int(),
+		Buttons:      event.Get("buttons").Int(),
+		Modifiers:    modifiersOf(event),
+	}
+}
+
+func submitEventContext(ctx EventContext) SubmitEventContext {
+	result := SubmitEventContext{
+		EventContext: ctx,
+		Values:       url.Values{},
+		Files:        map[string][]File{},
+	}
+	form := ctx.Event.Underlying().Get("target")
+	entries := js.Global().Get("Array").Call("from", js.Global().Get("FormData").New(form).Call("entries"))
+	for index := 0; index < entries.Length(); index++ {
+		entry := entries.Index(index)
+		name := entry.Index(0).String()
+		value := entry.Index(1)
+		if value.Type() == js.TypeString {
+			result.Values.Add(name, value.String())
+		} else {
+			result.Files[name] = append(result.Files[name], wrapFile(value))
+		}
+	}
+	return result
+}
+
+func dropEventContext(ctx EventContext) DropEventContext {
+	result := DropEventContext{
+		MouseEventContext: mouseEventContext(ctx),
+	}
+	dataTransfer := ctx.Event.Underlying().Get("dataTransfer")
+	if dataTransfer.IsNull() || dataTransfer.IsUndefined() {
+		return result
+	}
+	result.Text = dataTransfer.Call("getData", "text/plain").String()
+	files := dataTransfer.Get("files")
+	for index := 0; index < files.Length(); index++ {
+		result.Files = append(result.Files, wrapFile(files.Index(index)))
+	}
+	return result
+}
+
+func wrapFile(file js.Value) File {
+	return File{
+		Name:   file.Get("name").String(),
+		Type:   file.Get("type").String(),
+		Size:   file.Get("size").Int(),
+		native: file,
+	}
+}
@@ -0,0 +1,100 @@
+//go:build js && wasm
+
+package hx
+
+import (
+	"syscall/js"
+
+	"honnef.co/go/js/dom/v2"
+)
+
+// The tests run under Node, which has no DOM. This is the small subset of
+// it that DiffRenderer uses. Run them with
+//
+//	PATH="$PATH:$(go env GOROOT)/lib/wasm" GOOS=js GOARCH=wasm go test .
+const fakeDOM = `(() => {
+	class Node {
+		constructor() { this.childNodes = []; this.parentNode = null; }
+		get firstChild() { return this.childNodes[0] || null; }
+		get nextSibling() {
+			if (!this.parentNode) return null;
+			const siblings = this.parentNode.childNodes;
+			return siblings[siblings.indexOf(this) + 1] || null;
+		}
+		insertBefore(node, before) {
+			node.remove();
+			const index = before ? this.childNodes.indexOf(before) : -1;
+			if (index < 0) this.childNodes.push(node); else this.childNodes.splice(index, 0, node);
+			node.parentNode = this;
+			return node;
+		}
+		get lastChild() { return this.childNodes[this.childNodes.length - 1] || null; }
+		appendChild(node) { return this.insertBefore(node, null); }
+		removeChild(node) { node.remove(); return node; }
+		remove() {
+			if (!this.parentNode) return;
+			const siblings = this.parentNode.childNodes;
+			siblings.splice(siblings.indexOf(this), 1);
+			this.parentNode = null;
+		}
+		addEventListener() {}
+		removeEventListener() {}
+	}
+	class Text extends Node {
+		constructor(value) { super(); this.nodeType = 3; this.nodeName = "#text"; this.nodeValue = value; }
+	}
+	class Comment extends Node {
+		constructor(value) { super(); this.nodeType = 8; this.nodeName = "#comment"; this.nodeValue = value; }
+	}
+	class Element extends Node {
+		constructor(tag, namespace) {
+			super();
+			this.nodeType = 1;
+			this.namespaceURI = namespace || null;
+			this.nodeName = namespace ? tag : tag.toUpperCase();
+			this.attributes = new Map();
+			const classes = new Set();
+			this.classList = {
+				add: (c) => classes.add(c),
+				remove: (c) => classes.delete(c),
+				contains: (c) => classes.has(c),
+			};
+		}
+		setAttribute(name, value) { this.attributes.set(name, String(value)); }
+		removeAttribute(name) { this.attributes.delete(name); }
+		getAttribute(name) { return this.attributes.has(name) ? this.attributes.get(name) : null; }
+		set textContent(value) {
+			this.childNodes.forEach((child) => { child.parentNode = null; });
+			this.childNodes = [];
+			if (value !== "") this.appendChild(new Text(value));
+		}
+	}
+	const serialize = (node) => {
+		if (node.nodeType === 3) return node.nodeValue;
+		if (node.nodeType === 8) return "<!--" + node.nodeValue + "-->";
+		return "<" + node.nodeName + ">" + node.childNodes.map(serialize).join("") + "</" + node.nodeName + ">";
+	};
+	globalThis.Text = Text;
+	globalThis.document = {
+		createElement: (tag) => new Element(tag),
+		createElementNS: (ns, tag) => new Element(tag, ns),
+		createTextNode: (value) => new Text(value),
+		createComment: (value) => new Comment(value),
+	};
+	globalThis.requestAnimationFrame = () => 0;
+	globalThis.queueMicrotask = globalThis.queueMicrotask || ((fn) => Promise.resolve().then(fn));
+	return serialize;
+})()`
+
+var serializeDOM = js.Global().Call("eval", fakeDOM)
+
+func newTestRoot() (*VNode, *DiffRenderer, dom.Element) {
+	mount := dom.GetWindow().Document().CreateElement("div")
+	root := New(mount)
+	return root, root.renderer.(*DiffRenderer), mount
+}
+
+func innerDOM(mount dom.Element) string {
+	html := serializeDOM.Invoke(mount.Underlying()).String()
+	return html[len("<DIV>") : len(html)-len("</DIV>")]
+}
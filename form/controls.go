@@ -0,0 +1,53 @@
+package form
+
+import (
+	"time"
+
+	"github.com/deltegui/hx"
+)
+
+func Input(field *Field[string], input *hx.InputVNode) *hx.InputVNode {
+	named(&input.VNode, field)
+	input.BindValue(field.Value)
+	input.BindOnInput(field.Value)
+	return input
+}
+
+func TextArea(field *Field[string], textArea *hx.TextAreaNode) *hx.TextAreaNode {
+	named(&textArea.VNode, field)
+	textArea.BindValue(field.Value)
+	textArea.BindOnInput(field.Value)
+	return textArea
+}
+
+func Checkbox(field *Field[bool], input *hx.InputVNode) *hx.InputVNode {
+	named(&input.VNode, field)
+	return input.BindChecked(field.Value)
+}
+
+func Number[V hx.Number](field *Field[V], input *hx.InputVNode) *hx.InputVNode {
+	named(&input.VNode, field)
+	return hx.BindNumber(input, field.Value, field.parseErr)
+}
+
+func Date(field *Field[time.Time], input *hx.InputVNode) *hx.InputVNode {
+	named(&input.VNode, field)
+	return input.BindDate(field.Value, field.parseErr)
+}
+
+func Select(field *Field[string], selectNode *hx.SelectNode) *hx.SelectNode {
+	named(&selectNode.VNode, field)
+	return selectNode.BindSelected(field.Value)
+}
+
+// ErrorMessage renders the field error once the field has been touched.
+func ErrorMessage[V comparable](field *Field[V]) hx.INode {
+	return hx.Span().BindText(field.Message)
+}
+
+func named[V comparable](element *hx.VNode, field *Field[V]) {
+	element.Attribute("name", field.Name)
+	element.On(hx.EventFocusOut, func(ctx hx.EventContext) {
+		field.Touch()
+	})
+}
@@ -0,0 +1,191 @@
+package form
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/deltegui/hx"
+)
+
+type Validator[V any] func(value V) error
+
+type AsyncValidator[V any] func(ctx context.Context, value V) error
+
+var ErrRequired = errors.New("this field is required")
+
+func Required[V comparable]() Validator[V] {
+	return func(value V) error {
+		var zero V
+		if value == zero {
+			return ErrRequired
+		}
+		return nil
+	}
+}
+
+func MinLength(n int) Validator[string] {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) < n {
+			return fmt.Errorf("must be at least %d characters", n)
+		}
+		return nil
+	}
+}
+
+func MaxLength(n int) Validator[string] {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) > n {
+			return fmt.Errorf("must be at most %d characters", n)
+		}
+		return nil
+	}
+}
+
+type Field[V comparable] struct {
+	Name  string
+	Value *hx.SignalT[V]
+
+	Touched    *hx.ComputedT[bool]
+	Dirty      *hx.ComputedT[bool]
+	Validating *hx.ComputedT[bool]
+	Error      *hx.ComputedT[error]
+	Valid      *hx.ComputedT[bool]
+	Message    *hx.ComputedT[string]
+
+	initial    V
+	touched    *hx.SignalT[bool]
+	validating *hx.SignalT[bool]
+	parseErr   *hx.SignalT[error]
+	asyncErr   *hx.SignalT[error]
+	validators []Validator[V]
+	async      []AsyncValidator[V]
+	asyncRun   *hx.Effect
+}
+
+// NewField registers a field on form. bind points into the model so the
+// field starts from the form's initial value and writes back on submit.
+func NewField[T any, V comparable](form *Form[T], name string, bind func(model *T) *V, validators ...Validator[V]) *Field[V] {
+	initial := *bind(&form.initial)
+	field := &Field[V]{
+		Name:       name,
+		Value:      hx.Signal(initial),
+		initial:    initial,
+		touched:    hx.Signal(false),
+		validating: hx.Signal(false),
+		parseErr:   hx.Signal[error](nil),
+		asyncErr:   hx.Signal[error](nil),
+		validators: validators,
+	}
+	field.Touched = hx.Computed(field.touched.Get)
+	field.Dirty = hx.Computed(func() bool {
+		return field.Value.Get() != field.initial
+	})
+	field.Validating = hx.Computed(field.validating.Get)
+	field.Error = hx.Computed(func() error {
+		if err := field.parseErr.Get(); err != nil {
+			return err
+		}
+		if err := field.validate(field.Value.Get()); err != nil {
+			return err
+		}
+		return field.asyncErr.Get()
+	})
+	field.Valid = hx.Computed(func() bool {
+		return field.Error.Get() == nil && !field.Validating.Get()
+	})
+	field.Message = hx.Computed(func() string {
+		err := field.Error.Get()
+		if err == nil || !field.Touched.Get() {
+			return ""
+		}
+		return err.Error()
+	})
+
+	form.register(field, func(model *T) {
+		*bind(model) = hx.UntrackGet[V](field.Value)
+	})
+	return field
+}
+
+func (field *Field[V]) validate(value V) error {
+	for _, validator := range field.validators {
+		if err := validator(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateAsync runs validators after the sync ones pass. A run still in
+// flight when the value changes again is cancelled and its result dropped.
+func (field *Field[V]) ValidateAsync(validators ...AsyncValidator[V]) *Field[V] {
+	field.async = append(field.async, validators...)
+	if field.asyncRun != nil {
+		field.asyncRun.Dispose()
+	}
+	field.asyncRun = hx.EffectFunc(field.runAsync)
+	return field
+}
+
+func (field *Field[V]) runAsync() {
+	value := field.Value.Get()
+	if hx.UntrackGet[error](field.parseErr) != nil || field.validate(value) != nil {
+		hx.Batch(func() {
+			field.asyncErr.Set(nil)
+			field.validating.Set(false)
+		})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	hx.OnCleanup(cancel)
+	hx.Batch(func() {
+		field.asyncErr.Set(nil)
+		field.validating.Set(true)
+	})
+
+	validators := field.async
+	go func() {
+		var err error
+		for _, validator := range validators {
+			if err = validator(ctx, value); err != nil {
+				break
+			}
+		}
+		hx.RunOnRenderLoop(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			hx.Batch(func() {
+				field.asyncErr.Set(err)
+				field.validating.Set(false)
+			})
+		})
+	}()
+}
+
+func (field *Field[V]) Touch() {
+	field.touched.Set(true)
+}
+
+func (field *Field[V]) Reset() {
+	hx.Batch(func() {
+		field.Value.Set(field.initial)
+		field.touched.Set(false)
+		field.parseErr.Set(nil)
+	})
+}
+
+func (field *Field[V]) touch() {
+	field.Touch()
+}
+
+func (field *Field[V]) reset() {
+	field.Reset()
+}
+
+func (field *Field[V]) flags() (touched, dirty, valid bool) {
+	return field.Touched.Get(), field.Dirty.Get(), field.Valid.Get()
+}
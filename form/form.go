@@ -0,0 +1,128 @@
+package form
+
+import (
+	"github.com/deltegui/hx"
+)
+
+type control interface {
+	touch()
+	reset()
+	flags() (touched, dirty, valid bool)
+}
+
+type Form[T any] struct {
+	Touched     *hx.ComputedT[bool]
+	Dirty       *hx.ComputedT[bool]
+	Valid       *hx.ComputedT[bool]
+	Submitting  *hx.SignalT[bool]
+	SubmitError *hx.SignalT[error]
+
+	initial  T
+	controls *hx.SignalT[[]control]
+	appliers []func(model *T)
+}
+
+func New[T any](initial T) *Form[T] {
+	form := &Form[T]{
+		Submitting:  hx.Signal(false),
+		SubmitError: hx.Signal[error](nil),
+		initial:     initial,
+		controls:    hx.Signal([]control{}),
+	}
+	form.Touched = hx.Computed(func() bool {
+		for _, control := range form.controls.Get() {
+			if touched, _, _ := control.flags(); touched {
+				return true
+			}
+		}
+		return false
+	})
+	form.Dirty = hx.Computed(func() bool {
+		for _, control := range form.controls.Get() {
+			if _, dirty, _ := control.flags(); dirty {
+				return true
+			}
+		}
+		return false
+	})
+	form.Valid = hx.Computed(func() bool {
+		for _, control := range form.controls.Get() {
+			if _, _, valid := control.flags(); !valid {
+				return false
+			}
+		}
+		return true
+	})
+	return form
+}
+
+func (form *Form[T]) register(field control, apply func(model *T)) {
+	form.appliers = append(form.appliers, apply)
+	form.controls.Update(func(controls []control) []control {
+		return append(controls, field)
+	})
+}
+
+// Value returns a copy of the initial model with every field value
+// written into it.
+func (form *Form[T]) Value() T {
+	model := form.initial
+	for _, apply := range form.appliers {
+		apply(&model)
+	}
+	return model
+}
+
+func (form *Form[T]) TouchAll() {
+	hx.Batch(func() {
+		for _, control := range hx.UntrackGet[[]control](form.controls) {
+			control.touch()
+		}
+	})
+}
+
+func (form *Form[T]) Reset() {
+	hx.Batch(func() {
+		for _, control := range hx.UntrackGet[[]control](form.controls) {
+			control.reset()
+		}
+		form.SubmitError.Set(nil)
+	})
+}
+
+// Bind handles the submit event of element. Every field is marked as
+// touched and submit only runs when the form is valid. submit runs in its
+// own goroutine so it can block on network calls, and Submitting stays
+// true until it returns.
+func (form *Form[T]) Bind(element hx.INode, submit func(value T) error) hx.INode {
+	return element.AsVNode().OnSubmit(func(ctx hx.SubmitEventContext) {
+		if hx.UntrackGet[bool](form.Submitting) {
+			return
+		}
+		form.TouchAll()
+		if !form.Valid.Get() {
+			return
+		}
+		value := form.Value()
+		hx.Batch(func() {
+			form.SubmitError.Set(nil)
+			form.Submitting.Set(true)
+		})
+		go func() {
+			err := submit(value)
+			hx.RunOnRenderLoop(func() {
+				hx.Batch(func() {
+					form.SubmitError.Set(err)
+					form.Submitting.Set(false)
+				})
+			})
+		}()
+	})
+}
+
+func (form *Form[T]) SubmitButton(button hx.INode) hx.INode {
+	button.Attribute("type", "submit")
+	return button.AsVNode().BindProperty("disabled", func() any {
+		return form.Submitting.Get()
+	})
+}
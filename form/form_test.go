@@ -0,0 +1,58 @@
+//go:build !(js && wasm)
+
+package form
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/deltegui/hx"
+)
+
+type user struct {
+	Name string
+}
+
+func TestAsyncValidationLandsOnRenderLoop(t *testing.T) {
+	taken := errors.New("taken")
+	signup := New(user{})
+	name := NewField(signup, "name", func(u *user) *string { return &u.Name }, Required[string]())
+	name.ValidateAsync(func(ctx context.Context, value string) error {
+		if value == "bob" {
+			return taken
+		}
+		return nil
+	})
+
+	name.Value.Set("bob")
+	if !name.Validating.Get() {
+		t.Fatal("expected field to be validating")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for name.Validating.Get() {
+		if err := hx.WaitRenderLoop(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := name.Error.Get(); err != taken {
+		t.Errorf("expected %v, got %v", taken, err)
+	}
+	if signup.Valid.Get() {
+		t.Error("expected form to be invalid")
+	}
+}
+
+func TestSubmitButtonAcceptsBuiltNodes(t *testing.T) {
+	signup := New(user{})
+	renderer := &hx.StringRenderer{}
+	renderer.Render(signup.SubmitButton(hx.Button().Text("Sign up")).AsVNode())
+
+	if got, want := renderer.String(), `<BUTTON type="submit">Sign up</BUTTON>`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,245 @@
+package hx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+func (element *InputVNode) BindChecked(signal Bindable[bool]) *InputVNode {
+	element.ownEffect(func() {
+		element.Property("checked", signal.Get())
+		element.scheludeRender()
+	})
+	element.On(EventChange, func(ctx EventContext) {
+		checked := inputChecked(ctx)
+		element.syncProperty("checked", checked)
+		signal.Set(checked)
+	})
+	return element
+}
+
+// BindRadio checks the input while signal holds value and stores value
+// in signal when the user picks it. Give every radio of a group the same
+// signal.
+func BindRadio[T comparable](element *InputVNode, value T, signal Bindable[T]) *InputVNode {
+	element.ownEffect(func() {
+		element.Property("checked", signal.Get() == value)
+		element.scheludeRender()
+	})
+	element.On(EventChange, func(ctx EventContext) {
+		checked := inputChecked(ctx)
+		element.syncProperty("checked", checked)
+		if checked {
+			signal.Set(value)
+		}
+	})
+	return element
+}
+
+// BindNumber keeps a numeric signal in sync with the input. Text that
+// cannot be parsed leaves signal untouched and is reported through
+// parseErr, which is reset to nil on the next valid input.
+func BindNumber[T Number](element *InputVNode, signal Bindable[T], parseErr Settable[error]) *InputVNode {
+	bindParsed(element, signal, parseErr, parseNumber[T], formatNumber[T])
+	return element
+}
+
+func parseNumber[T Number](raw string) (T, error) {
+	kind := reflect.TypeFor[T]().Kind()
+	bits := reflect.TypeFor[T]().Bits()
+	var value T
+	var err error
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var parsed int64
+		parsed, err = strconv.ParseInt(raw, 10, bits)
+		value = T(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var parsed uint64
+		parsed, err = strconv.ParseUint(raw, 10, bits)
+		value = T(parsed)
+	default:
+		var parsed float64
+		parsed, err = strconv.ParseFloat(raw, bits)
+		value = T(parsed)
+	}
+	if err == nil {
+		return value, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("%q is out of range for %T", raw, value)
+	}
+	if parsed, floatErr := strconv.ParseFloat(raw, 64); floatErr == nil && parsed != math.Trunc(parsed) {
+		return 0, fmt.Errorf("%q is not an integer", raw)
+	}
+	return 0, err
+}
+
+func formatNumber[T Number](value T) string {
+	number := reflect.ValueOf(value)
+	switch {
+	case number.CanInt():
+		return strconv.FormatInt(number.Int(), 10)
+	case number.CanUint():
+		return strconv.FormatUint(number.Uint(), 10)
+	default:
+		return strconv.FormatFloat(number.Float(), 'f', -1, number.Type().Bits())
+	}
+}
+
+// BindDate keeps a time signal in sync with a date, datetime-local, time
+// or month input. An empty input stores the zero time.
+func (element *InputVNode) BindDate(signal Bindable[time.Time], parseErr Settable[error]) *InputVNode {
+	layout := dateLayout(element.GetAttribute("type"))
+	parse := func(raw string) (time.Time, error) {
+		if len(raw) == 0 {
+			return time.Time{}, nil
+		}
+		return time.Parse(layout, raw)
+	}
+	format := func(value time.Time) string {
+		if value.IsZero() {
+			return ""
+		}
+		return value.Format(layout)
+	}
+	bindParsed(element, signal, parseErr, parse, format)
+	return element
+}
+
+func dateLayout(inputType string) string {
+	switch inputType {
+	case "datetime-local":
+		return "2006-01-02T15:04"
+	case "time":
+		return "15:04"
+	case "month":
+		return "2006-01"
+	default:
+		return time.DateOnly
+	}
+}
+
+// bindParsed leaves the text typed by the user alone while it still parses
+// to the signal value, so "1." is not rewritten to "1" mid typing.
+func bindParsed[T comparable](element *InputVNode, signal Bindable[T], parseErr Settable[error], parse func(string) (T, error), format func(T) string) {
+	raw := ""
+	element.ownEffect(func() {
+		value := signal.Get()
+		if current, err := parse(raw); err == nil && current == value && element.value.Value() == raw {
+			return
+		}
+		raw = format(value)
+		element.Value(raw)
+		element.scheludeRender()
+	})
+	element.On(EventInput, func(ctx EventContext) {
+		raw = inputValue(ctx)
+		element.value.sync(raw)
+		value, err := parse(raw)
+		if parseErr != nil {
+			parseErr.Set(err)
+		}
+		if err == nil {
+			signal.Set(value)
+		}
+	})
+}
+
+func (element *SelectNode) BindSelected(signal Bindable[string]) *SelectNode {
+	element.ownEffect(func() {
+		element.selection = map[string]bool{signal.Get(): true}
+		element.applySelection()
+		element.scheludeRender()
+	})
+	element.On(EventChange, func(ctx EventContext) {
+		value := inputValue(ctx)
+		element.selection = map[string]bool{value: true}
+		element.syncSelection()
+		signal.Set(value)
+	})
+	return element
+}
+
+func (element *SelectNode) BindSelectedValues(signal Bindable[[]string]) *SelectNode {
+	element.Property("multiple", true)
+	element.ownEffect(func() {
+		element.selection = selectionOf(signal.Get())
+		element.applySelection()
+		element.scheludeRender()
+	})
+	element.On(EventChange, func(ctx EventContext) {
+		values := selectedValues(ctx)
+		element.selection = selectionOf(values)
+		element.syncSelection()
+		signal.Set(values)
+	})
+	return element
+}
+
+func selectionOf(values []string) map[string]bool {
+	selection := make(map[string]bool, len(values))
+	for _, value := range values {
+		selection[value] = true
+	}
+	return selection
+}
+
+func (element *SelectNode) applySelection() {
+	if element.selection == nil {
+		return
+	}
+	eachOption(&element.VNode, func(option *VNode) {
+		option.Property("selected", element.selection[optionValue(option)])
+	})
+}
+
+// adoptSelection selects an option that joins a bound select after the
+// selection was applied, as happens with options rendered by Each.
+func (option *VNode) adoptSelection() {
+	for parent := option.father; parent != nil; parent = parent.father {
+		if parent.tag != "SELECT" {
+			continue
+		}
+		if selectNode, ok := parent.Owner.(*SelectNode); ok && selectNode.selection != nil {
+			option.Property("selected", selectNode.selection[optionValue(option)])
+		}
+		return
+	}
+}
+
+func (element *SelectNode) syncSelection() {
+	eachOption(&element.VNode, func(option *VNode) {
+		option.syncProperty("selected", element.selection[optionValue(option)])
+	})
+}
+
+func eachOption(element *VNode, fn func(option *VNode)) {
+	for _, child := range element.children {
+		if child == nil || child.status == changeDeleted {
+			continue
+		}
+		if child.tag == "OPTION" {
+			fn(child)
+		} else {
+			eachOption(child, fn)
+		}
+	}
+}
+
+func optionValue(option *VNode) string {
+	if value := option.value.Value(); len(value) > 0 {
+		return value
+	}
+	return option.text.Value()
+}
@@ -0,0 +1,65 @@
+package hx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNumber(t *testing.T) {
+	if _, err := parseNumber[int]("1.5"); err == nil || !strings.Contains(err.Error(), "not an integer") {
+		t.Errorf("expected not an integer error, got %v", err)
+	}
+	if _, err := parseNumber[uint8]("300"); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("expected out of range error, got %v", err)
+	}
+	if value, err := parseNumber[float32]("1.5"); err != nil || value != 1.5 {
+		t.Errorf("expected 1.5, got %v (%v)", value, err)
+	}
+	if value, err := parseNumber[int]("42"); err != nil || value != 42 {
+		t.Errorf("expected 42, got %v (%v)", value, err)
+	}
+}
+
+func TestParseNumberUsesTheTypeSize(t *testing.T) {
+	if value, err := parseNumber[float32]("0.1"); err != nil || value != 0.1 {
+		t.Errorf("expected 0.1, got %v (%v)", value, err)
+	}
+	if value, err := parseNumber[int64]("9007199254740993"); err != nil || value != 9007199254740993 {
+		t.Errorf("expected 9007199254740993, got %v (%v)", value, err)
+	}
+	if _, err := parseNumber[float32]("1e40"); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("expected out of range error, got %v", err)
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	if got := formatNumber[float32](0.1); got != "0.1" {
+		t.Errorf("expected 0.1, got %s", got)
+	}
+	if got := formatNumber[int64](9007199254740993); got != "9007199254740993" {
+		t.Errorf("expected 9007199254740993, got %s", got)
+	}
+	if got := formatNumber[uint8](255); got != "255" {
+		t.Errorf("expected 255, got %s", got)
+	}
+}
+
+func TestBindSelectedReachesOptionsAddedLater(t *testing.T) {
+	opts := Signal([]string{"a"})
+	options := map[string]*OptionNode{}
+	Select().BindSelected(Signal("b")).Body(
+		Each(opts, func(index int, value string) INode {
+			options[value] = Option().Value(value)
+			return options[value]
+		}),
+	)
+
+	opts.Set([]string{"a", "b"})
+
+	if got := options["b"].GetProperty("selected"); got != true {
+		t.Errorf("expected option b to be selected, got %v", got)
+	}
+	if got := options["a"].GetProperty("selected"); got != false {
+		t.Errorf("expected option a not to be selected, got %v", got)
+	}
+}
@@ -0,0 +1,157 @@
+//go:build js && wasm
+
+package hx
+
+import (
+	"log"
+	"strings"
+
+	"honnef.co/go/js/dom/v2"
+)
+
+const (
+	elementNodeType = 1
+	textNodeType    = 3
+	commentNodeType = 8
+)
+
+func Hydrate(mount dom.Element, tree INode) *VNode {
+	root := New(mount)
+	node := asVNode(tree)
+	node.father = root
+	node.setRenderer(root.renderer, root.haveRenderer)
+	root.children = append(root.children, node)
+
+	renderer := root.renderer.(*DiffRenderer)
+	rest := renderer.hydrateChildren(root, mount.FirstChild())
+	removeLeftovers(mount, rest, mount.NodeName())
+
+	root.setDirty(flagChildren)
+	root.scheludeRender()
+	return root
+}
+
+func (renderer *DiffRenderer) hydrateChildren(parent *VNode, cursor dom.Node) dom.Node {
+	for _, child := range parent.children {
+		if child == nil {
+			continue
+		}
+		if child.tag == textIdNode {
+			cursor = renderer.hydrateText(child, cursor)
+			continue
+		}
+		cursor = renderer.hydrateNode(child, skipTextNodes(cursor))
+	}
+	return skipTextNodes(cursor)
+}
+
+func (renderer *DiffRenderer) hydrateText(element *VNode, cursor dom.Node) dom.Node {
+	if isMarker(cursor, textSeparatorMarker) {
+		cursor = cursor.NextSibling()
+	}
+	text, ok := cursor.(*dom.Text)
+	if !ok {
+		if len(element.text.Value()) > 0 {
+			log.Printf("Warning: hydration mismatch: expected text, found %s", describeDomNode(cursor))
+		}
+		element.status = changeNew
+		return cursor
+	}
+
+	element.domText = text
+	element.haveDomElement = true
+	element.status = unchanged
+	if text.NodeValue() == element.text.Value() {
+		element.text.tick()
+	}
+	return cursor.NextSibling()
+}
+
+func (renderer *DiffRenderer) hydrateNode(element *VNode, cursor dom.Node) dom.Node {
+	if element.tag == noopIdNode {
+		return renderer.hydrateFragment(element, cursor)
+	}
+
+	if cursor == nil || cursor.NodeType() != elementNodeType || !strings.EqualFold(cursor.NodeName(), element.tag) {
+		log.Printf("Warning: hydration mismatch: expected %s, found %s", element.tag, describeDomNode(cursor))
+		element.status = changeNew
+		return cursor
+	}
+
+	domElement := dom.WrapElement(cursor.Underlying())
+	element.domElement = domElement
+	element.haveDomElement = true
+	element.status = unchanged
+
+	if element.text.status != unchanged {
+		last := domElement.LastChild()
+		if last != nil && last.NodeType() == textNodeType && last.NodeValue() == element.text.Value() {
+			element.text.tick()
+		}
+	}
+
+	rest := renderer.hydrateChildren(element, domElement.FirstChild())
+	removeLeftovers(domElement, rest, element.tag)
+
+	return cursor.NextSibling()
+}
+
+func removeLeftovers(parent dom.Element, rest dom.Node, name string) {
+	for rest != nil {
+		next := rest.NextSibling()
+		if rest.NodeType() != textNodeType {
+			log.Printf("Warning: hydration mismatch: unexpected %s inside %s", describeDomNode(rest), name)
+			parent.RemoveChild(rest)
+		}
+		rest = next
+	}
+}
+
+func (renderer *DiffRenderer) hydrateFragment(element *VNode, cursor dom.Node) dom.Node {
+	if !isMarker(cursor, fragmentOpenMarker) {
+		log.Printf("Warning: hydration mismatch: expected fragment, found %s", describeDomNode(cursor))
+		element.status = changeNew
+		return cursor
+	}
+	element.status = unchanged
+
+	cursor = renderer.hydrateChildren(element, cursor.NextSibling())
+	for cursor != nil && !isMarker(cursor, fragmentCloseMarker) {
+		next := cursor.NextSibling()
+		log.Printf("Warning: hydration mismatch: unexpected %s inside fragment", describeDomNode(cursor))
+		cursor.ParentNode().RemoveChild(cursor)
+		cursor = next
+	}
+
+	if element.reactive {
+		element.placed = make([]*VNode, 0, len(element.children))
+		for _, child := range element.children {
+			if child != nil && child.status == unchanged {
+				element.placed = append(element.placed, child)
+			}
+		}
+	}
+
+	if cursor == nil {
+		return nil
+	}
+	return cursor.NextSibling()
+}
+
+func skipTextNodes(cursor dom.Node) dom.Node {
+	for cursor != nil && cursor.NodeType() == textNodeType {
+		cursor = cursor.NextSibling()
+	}
+	return cursor
+}
+
+func isMarker(node dom.Node, marker string) bool {
+	return node != nil && node.NodeType() == commentNodeType && node.NodeValue() == marker
+}
+
+func describeDomNode(node dom.Node) string {
+	if node == nil {
+		return "nothing"
+	}
+	return node.NodeName()
+}
@@ -0,0 +1,41 @@
+//go:build js && wasm
+
+package hx
+
+import (
+	"testing"
+
+	"honnef.co/go/js/dom/v2"
+)
+
+func TestHydrateRemovesUnexpectedTopLevelNodes(t *testing.T) {
+	document := dom.GetWindow().Document()
+	mount := document.CreateElement("div")
+	paragraph := document.CreateElement("p")
+	paragraph.AppendChild(document.CreateTextNode("kept"))
+	mount.AppendChild(paragraph)
+	mount.AppendChild(document.CreateElement("section"))
+
+	root := Hydrate(mount, P().Body(T("kept")))
+	root.renderer.(*DiffRenderer).render()
+
+	if got, want := innerDOM(mount), "<P>kept</P>"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if !mount.FirstChild().Underlying().Equal(paragraph.Underlying()) {
+		t.Error("expected the server rendered paragraph to be reused")
+	}
+}
+
+func TestHydrateReplacesMismatchedTopLevelNode(t *testing.T) {
+	document := dom.GetWindow().Document()
+	mount := document.CreateElement("div")
+	mount.AppendChild(document.CreateElement("span"))
+
+	root := Hydrate(mount, P().Text("new"))
+	root.renderer.(*DiffRenderer).render()
+
+	if got, want := innerDOM(mount), "<P>new</P>"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,227 @@
+package hx
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+var propertyAttributes = map[string]string{
+	"checked":  "checked",
+	"selected": "selected",
+	"disabled": "disabled",
+	"readOnly": "readonly",
+	"required": "required",
+	"multiple": "multiple",
+	"hidden":   "hidden",
+	"open":     "open",
+	"value":    "value",
+	"htmlFor":  "for",
+	"tabIndex": "tabindex",
+	"title":    "title",
+}
+
+var voidElements = map[string]bool{
+	"AREA": true, "BASE": true, "BR": true, "COL": true,
+	"EMBED": true, "HR": true, "IMG": true, "INPUT": true,
+	"LINK": true, "META": true, "PARAM": true, "SOURCE": true,
+	"TRACK": true, "WBR": true,
+}
+
+const (
+	fragmentOpenMarker  = "["
+	fragmentCloseMarker = "]"
+	textSeparatorMarker = ""
+)
+
+type markupSink interface {
+	io.StringWriter
+	WriteRune(r rune) (int, error)
+}
+
+type markupWriter struct {
+	out      markupSink
+	boundary func() error
+	err      error
+}
+
+func (w *markupWriter) writeString(s string) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = w.out.WriteString(s)
+}
+
+func (w *markupWriter) writeRune(r rune) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = w.out.WriteRune(r)
+}
+
+func (w *markupWriter) render(current *VNode) {
+	if w.err != nil {
+		return
+	}
+	if current.tag == noopIdNode {
+		w.writeFragment(current)
+		return
+	}
+	if current.tag == textIdNode {
+		w.writeString(html.EscapeString(current.text.Value()))
+		return
+	}
+	w.writeOpenTag(current)
+	if !current.selfClosing() {
+		w.writeBody(current)
+	}
+	w.writeCloseTag(current)
+	if w.boundary != nil && w.err == nil {
+		w.err = w.boundary()
+	}
+}
+
+func (w *markupWriter) writeFragment(current *VNode) {
+	w.writeComment(fragmentOpenMarker)
+	w.writeChildren(current)
+	w.writeComment(fragmentCloseMarker)
+}
+
+func (w *markupWriter) writeChildren(current *VNode) {
+	previousIsText := false
+	for _, child := range current.children {
+		if child == nil || child.status == changeDeleted {
+			continue
+		}
+		isText := child.tag == textIdNode
+		if isText && previousIsText {
+			w.writeComment(textSeparatorMarker)
+		}
+		w.render(child)
+		previousIsText = isText
+	}
+}
+
+func (w *markupWriter) writeComment(comment string) {
+	w.writeString("<!--")
+	w.writeString(comment)
+	w.writeString("-->")
+}
+
+func (w *markupWriter) writeBody(current *VNode) {
+	w.writeChildren(current)
+	if current.text.status == changeModified {
+		w.writeString(html.EscapeString(current.text.nextValue))
+	}
+}
+
+func (w *markupWriter) writeCloseTag(current *VNode) {
+	if current.selfClosing() {
+		return
+	}
+	w.writeString("</")
+	w.writeString(current.tag)
+	w.writeString(">")
+}
+
+func (w *markupWriter) writeOpenTag(current *VNode) {
+	w.writeRune('<')
+	w.writeString(current.tag)
+	w.writeAttributes(current)
+
+	if current.selfClosing() {
+		w.writeString("/>")
+	} else {
+		w.writeRune('>')
+	}
+}
+
+func (w *markupWriter) writeAttributes(current *VNode) {
+	if id := current.id.Value(); len(id) > 0 {
+		w.writeAttribute("id", id)
+	}
+
+	classes := make([]string, 0, len(current.classOrder))
+	for _, class := range current.classOrder {
+		if current.HaveClass(class) {
+			classes = append(classes, class)
+		}
+	}
+	if len(classes) > 0 {
+		w.writeAttribute("class", strings.Join(classes, " "))
+	}
+
+	styles := strings.Builder{}
+	for _, styleName := range current.styleOrder {
+		styleValue := current.styles[styleName]
+		if styleValue.status == changeDeleted {
+			continue
+		}
+		styles.WriteString(styleName)
+		styles.WriteRune(':')
+		styles.WriteString(styleValue.Value())
+		styles.WriteRune(';')
+	}
+	if styles.Len() > 0 {
+		w.writeAttribute("style", styles.String())
+	}
+
+	for _, attrName := range current.attributeOrder {
+		attrValue := current.attributes[attrName]
+		if attrValue.status == changeDeleted {
+			continue
+		}
+		w.writeAttribute(attrName, attrValue.Value())
+	}
+
+	if value := current.value.Value(); len(value) > 0 && current.tag != "TEXTAREA" {
+		w.writeAttribute("value", value)
+	}
+
+	w.writeProperties(current)
+}
+
+func (w *markupWriter) writeProperties(current *VNode) {
+	names := make([]string, 0, len(current.properties))
+	for name := range current.properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attrName, ok := propertyAttributes[name]
+		if !ok || current.GetAttribute(attrName) != "" {
+			continue
+		}
+		if name == "value" && len(current.value.Value()) > 0 {
+			continue
+		}
+		switch value := current.properties[name].Value().(type) {
+		case nil:
+		case bool:
+			if value {
+				w.writeRune(' ')
+				w.writeString(attrName)
+			}
+		default:
+			w.writeAttribute(attrName, fmt.Sprint(value))
+		}
+	}
+}
+
+func (w *markupWriter) writeAttribute(name, value string) {
+	w.writeRune(' ')
+	w.writeString(name)
+	w.writeString(`="`)
+	w.writeString(html.EscapeString(value))
+	w.writeRune('"')
+}
+
+func (element *VNode) selfClosing() bool {
+	if len(element.namespace) > 0 {
+		return len(element.children) == 0 && element.text.status != changeModified
+	}
+	return voidElements[element.tag]
+}
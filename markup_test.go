@@ -0,0 +1,67 @@
+package hx
+
+import "testing"
+
+func renderString(node INode) string {
+	renderer := &StringRenderer{}
+	renderer.Render(asVNode(node))
+	return renderer.String()
+}
+
+func TestValuePropertyIsWrittenOnce(t *testing.T) {
+	input := Input()
+	input.Value("a")
+	input.Property("value", "b")
+
+	if got, want := renderString(input), `<INPUT value="a"/>`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestMarkupIsDeterministic(t *testing.T) {
+	build := func() INode {
+		return Div().
+			Class("b", "a", "c").
+			Style("color", "red").
+			Style("margin", "0").
+			Attribute("title", "t").
+			Attribute("data-x", "1").
+			Attribute("aria-label", "l").
+			Body(Span(), Img())
+	}
+	want := `<DIV class="b a c" style="color:red;margin:0;" title="t" data-x="1" aria-label="l"><SPAN></SPAN><IMG/></DIV>`
+	for range 20 {
+		if got := renderString(build()); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestMarkupSkipsRemovedClasses(t *testing.T) {
+	element := Div().Class("a", "b")
+	element.RemoveClass("a")
+	element.RemoveClass("b")
+
+	if got, want := renderString(element), `<DIV></DIV>`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestDisposedRenderReleasesSubscribers(t *testing.T) {
+	visible := Signal(true)
+	items := Signal([]string{"a"})
+	CreateRoot(func(dispose func()) {
+		defer dispose()
+		renderer := &StringRenderer{}
+		root := NewWithoutMount("DIV", renderer)
+		root.Body(
+			If(visible, P().Text("shown")),
+			Each(items, func(index int, item string) INode { return Li().Text(item) }),
+		)
+		renderer.Render(root)
+	})
+
+	if len(visible.subscribers) != 0 || len(items.subscribers) != 0 {
+		t.Errorf("expected no subscribers after dispose, got %d and %d", len(visible.subscribers), len(items.subscribers))
+	}
+}
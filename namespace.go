@@ -0,0 +1,39 @@
+package hx
+
+const (
+	NamespaceSVG    = "http://www.w3.org/2000/svg"
+	NamespaceMathML = "http://www.w3.org/1998/Math/MathML"
+)
+
+func Svg() *VNode            { return newNSVNode(NamespaceSVG, "svg") }
+func G() *VNode              { return newNSVNode(NamespaceSVG, "g") }
+func Path() *VNode           { return newNSVNode(NamespaceSVG, "path") }
+func Circle() *VNode         { return newNSVNode(NamespaceSVG, "circle") }
+func Ellipse() *VNode        { return newNSVNode(NamespaceSVG, "ellipse") }
+func Rect() *VNode           { return newNSVNode(NamespaceSVG, "rect") }
+func Line() *VNode           { return newNSVNode(NamespaceSVG, "line") }
+func Polyline() *VNode       { return newNSVNode(NamespaceSVG, "polyline") }
+func Polygon() *VNode        { return newNSVNode(NamespaceSVG, "polygon") }
+func SvgText() *VNode        { return newNSVNode(NamespaceSVG, "text") }
+func TSpan() *VNode          { return newNSVNode(NamespaceSVG, "tspan") }
+func Defs() *VNode           { return newNSVNode(NamespaceSVG, "defs") }
+func Use() *VNode            { return newNSVNode(NamespaceSVG, "use") }
+func Symbol() *VNode         { return newNSVNode(NamespaceSVG, "symbol") }
+func ClipPath() *VNode       { return newNSVNode(NamespaceSVG, "clipPath") }
+func Mask() *VNode           { return newNSVNode(NamespaceSVG, "mask") }
+func LinearGradient() *VNode { return newNSVNode(NamespaceSVG, "linearGradient") }
+func RadialGradient() *VNode { return newNSVNode(NamespaceSVG, "radialGradient") }
+func Stop() *VNode           { return newNSVNode(NamespaceSVG, "stop") }
+func ForeignObject() *VNode  { return newNSVNode(NamespaceSVG, "foreignObject") }
+
+func Math() *VNode  { return newNSVNode(NamespaceMathML, "math") }
+func Mrow() *VNode  { return newNSVNode(NamespaceMathML, "mrow") }
+func Mi() *VNode    { return newNSVNode(NamespaceMathML, "mi") }
+func Mn() *VNode    { return newNSVNode(NamespaceMathML, "mn") }
+func Mo() *VNode    { return newNSVNode(NamespaceMathML, "mo") }
+func Mfrac() *VNode { return newNSVNode(NamespaceMathML, "mfrac") }
+func Msqrt() *VNode { return newNSVNode(NamespaceMathML, "msqrt") }
+func Msup() *VNode  { return newNSVNode(NamespaceMathML, "msup") }
+func Msub() *VNode  { return newNSVNode(NamespaceMathML, "msub") }
+
+func (element *VNode) ViewBox(v string) INode { return element.Attribute("viewBox", v) }
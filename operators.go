@@ -1,39 +1,233 @@
 package hx
 
+import (
+	"cmp"
+	"log"
+	"maps"
+	"slices"
+)
+
 func If(condition Gettable[bool], child INode) INode {
-	var element INode = nil
-	if condition.Get() {
-		element = child
-	}
-	return element
+	fragment := ShowFunc(condition, func() INode { return child }, nil)
+	return keepAlive(fragment, child)
 }
 
 func Show(condition Gettable[bool], ifPath, elsePath INode) INode {
-	var element INode = nil
-	if condition.Get() {
-		element = ifPath
-	} else {
-		element = elsePath
+	fragment := ShowFunc(condition, func() INode { return ifPath }, func() INode { return elsePath })
+	return keepAlive(fragment, ifPath, elsePath)
+}
+
+// keepAlive stops nodes from being disposed while fragment swaps them in
+// and out. They are disposed with fragment once it is removed for good.
+func keepAlive(fragment INode, nodes ...INode) INode {
+	owner := asVNode(fragment)
+	for _, node := range nodes {
+		if node != nil {
+			kept := asVNode(node)
+			kept.persistent = true
+			owner.kept = append(owner.kept, kept)
+		}
 	}
-	return element
+	return fragment
 }
 
-func Each[T any](src Gettable[[]T], renderOne func(index int, value T) INode) INode {
-	list := src.Get()
-	result := make([]INode, len(list))
-	for i, element := range list {
-		result[i] = renderOne(i, element)
+func ShowFunc(condition Gettable[bool], ifPath, elsePath func() INode) INode {
+	return Switch(Match(condition, ifPath), Default(elsePath))
+}
+
+type MatchCase struct {
+	condition Gettable[bool]
+	render    func() INode
+}
+
+func Match(condition Gettable[bool], render func() INode) MatchCase {
+	return MatchCase{
+		condition: condition,
+		render:    render,
 	}
-	return Noop().Body(result...)
 }
 
-func EachMap[K comparable, T any](src Gettable[map[K]T], renderOne func(index K, value T) INode) INode {
-	list := src.Get()
-	result := make([]INode, len(list))
-	i := 0
-	for key, element := range list {
-		result[i] = renderOne(key, element)
-		i++
+func Default(render func() INode) MatchCase {
+	return MatchCase{
+		condition: nil,
+		render:    render,
 	}
-	return Noop().Body(result...)
+}
+
+func Switch(cases ...MatchCase) INode {
+	active := Computed(func() int {
+		for index, current := range cases {
+			if current.condition == nil || current.condition.Get() {
+				return index
+			}
+		}
+		return -1
+	})
+	return renderBranch(func() func() INode {
+		index := active.Get()
+		if index < 0 {
+			return nil
+		}
+		return cases[index].render
+	})
+}
+
+func SwitchOn[T comparable](src Gettable[T], cases map[T]func() INode, fallback func() INode) INode {
+	key := Computed(func() T {
+		return src.Get()
+	})
+	return renderBranch(func() func() INode {
+		render, ok := cases[key.Get()]
+		if !ok {
+			return fallback
+		}
+		return render
+	})
+}
+
+func renderBranch(selectBranch func() func() INode) INode {
+	fragment := reactiveFragment()
+	fragment.ownEffect(func() {
+		branch := selectBranch()
+		var child INode = nil
+		if branch != nil {
+			untrackOwned(func() {
+				child = branch()
+			})
+		}
+		fragment.Body(child)
+	})
+	return fragment
+}
+
+func Each[T any](src Gettable[[]T], renderOne func(index int, value T) INode) INode {
+	fragment := reactiveFragment()
+	fragment.ownEffect(func() {
+		list := src.Get()
+		result := make([]INode, len(list))
+		untrackOwned(func() {
+			for i, element := range list {
+				result[i] = renderOne(i, element)
+			}
+		})
+		fragment.BodyList(result)
+	})
+	return fragment
+}
+
+// EachMap renders the entries sorted by key, so the output does not depend
+// on map iteration order.
+func EachMap[K cmp.Ordered, T any](src Gettable[map[K]T], renderOne func(index K, value T) INode) INode {
+	fragment := reactiveFragment()
+	fragment.ownEffect(func() {
+		list := src.Get()
+		result := make([]INode, 0, len(list))
+		untrackOwned(func() {
+			for _, key := range slices.Sorted(maps.Keys(list)) {
+				result = append(result, renderOne(key, list[key]))
+			}
+		})
+		fragment.BodyList(result)
+	})
+	return fragment
+}
+
+func reactiveFragment() *NoopNode {
+	fragment := Noop()
+	fragment.reactive = true
+	return fragment
+}
+
+type keyedRow[T any] struct {
+	index *SignalT[int]
+	value *SignalT[T]
+}
+
+func (row keyedRow[T]) update(index int, value T) {
+	if UntrackGet[int](row.index) != index {
+		row.index.Set(index)
+	}
+	current := UntrackGet[T](row.value)
+	if !isComparable(value) || any(current) != any(value) {
+		row.value.Set(value)
+	}
+}
+
+// EachKeyed keeps one row per key and moves it when the list is reordered.
+// A row is rendered once, when its key first appears. After that index and
+// value follow the position and the latest item with that key.
+func EachKeyed[T any, K comparable](src Gettable[[]T], key func(value T) K, renderOne func(index Gettable[int], value Gettable[T]) INode) INode {
+	fragment := reactiveFragment()
+	rendered := map[K]*VNode{}
+	signals := map[K]keyedRow[T]{}
+	disposers := map[K]func(){}
+	rows := newRoot()
+	rows.cleanUps = append(rows.cleanUps, func() {
+		for _, dispose := range disposers {
+			dispose()
+		}
+	})
+	fragment.effects = append(fragment.effects, rows)
+	OnCleanup(rows.Dispose)
+
+	fragment.ownEffect(func() {
+		list := src.Get()
+		next := make(map[K]*VNode, len(list))
+		kept := make(map[*VNode]struct{}, len(list))
+		children := make([]*VNode, 0, len(list))
+
+		for index, value := range list {
+			k := key(value)
+			if _, duplicated := next[k]; duplicated {
+				log.Printf("Warning: ignoring duplicated key in EachKeyed: %v", k)
+				continue
+			}
+			node, ok := rendered[k]
+			if ok {
+				signals[k].update(index, value)
+			} else {
+				row := keyedRow[T]{index: Signal(index), value: Signal(value)}
+				var child INode
+				CreateRoot(func(dispose func()) {
+					child = renderOne(row.index, row.value)
+					disposers[k] = dispose
+				})
+				if child == nil {
+					disposers[k]()
+					delete(disposers, k)
+					continue
+				}
+				signals[k] = row
+				node = asVNode(child)
+				node.status = changeNew
+				node.father = &fragment.VNode
+				node.setRenderer(fragment.renderer, fragment.haveRenderer)
+			}
+			next[k] = node
+			kept[node] = struct{}{}
+			children = append(children, node)
+		}
+
+		for _, old := range fragment.children {
+			if _, ok := kept[old]; ok {
+				continue
+			}
+			old.status = changeDeleted
+			children = append(children, old)
+		}
+		for k, dispose := range disposers {
+			if _, ok := next[k]; !ok {
+				dispose()
+				delete(disposers, k)
+				delete(signals, k)
+			}
+		}
+
+		rendered = next
+		fragment.children = children
+		fragment.setDirty(flagChildren)
+		fragment.scheludeRender()
+	})
+
+	return fragment
 }
@@ -0,0 +1,155 @@
+package hx
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestRemovedIfDisposesKeptBranches(t *testing.T) {
+	visible := Signal(false)
+	label := Signal("a")
+	shown := P().BindText(label)
+	hidden := P().BindText(label)
+	fragment := Show(visible, shown, hidden)
+
+	asVNode(fragment).disposeEffects()
+	label.Set("b")
+
+	if got := shown.AsVNode().text.Value(); got != "a" {
+		t.Errorf("expected shown branch to stop following the signal, got %q", got)
+	}
+	if got := hidden.AsVNode().text.Value(); got != "a" {
+		t.Errorf("expected hidden branch to stop following the signal, got %q", got)
+	}
+}
+
+func TestSwitchingKeepsBranchesAlive(t *testing.T) {
+	visible := Signal(true)
+	label := Signal("a")
+	child := P().BindText(label)
+	If(visible, child)
+
+	visible.Set(false)
+	asVNode(child).disposeEffects()
+	label.Set("b")
+
+	if got := child.AsVNode().text.Value(); got != "b" {
+		t.Errorf("expected kept branch to follow the signal, got %q", got)
+	}
+}
+
+func TestEachKeyedIndexFollowsReorder(t *testing.T) {
+	items := Signal([]string{"a", "b", "c"})
+	indexes := map[string]Gettable[int]{}
+	EachKeyed(items, func(item string) string { return item }, func(index Gettable[int], item Gettable[string]) INode {
+		indexes[UntrackGet(item)] = index
+		return Li().BindText(item)
+	})
+
+	items.Set([]string{"c", "a", "b"})
+
+	for item, want := range map[string]int{"c": 0, "a": 1, "b": 2} {
+		if got := UntrackGet(indexes[item]); got != want {
+			t.Errorf("expected %q at index %d, got %d", item, want, got)
+		}
+	}
+}
+
+type keyedUser struct {
+	id   int
+	name string
+}
+
+func TestEachKeyedRowsFollowUpdatedItems(t *testing.T) {
+	users := Signal([]keyedUser{{1, "ann"}, {2, "bob"}})
+	rows := map[int]*VNode{}
+	renders := 0
+	EachKeyed(users, func(user keyedUser) int { return user.id }, func(index Gettable[int], user Gettable[keyedUser]) INode {
+		renders++
+		row := Li().BindText(Computed(func() string { return user.Get().name }))
+		rows[UntrackGet(user).id] = row.AsVNode()
+		return row
+	})
+
+	users.Set([]keyedUser{{1, "ann"}, {2, "bobby"}})
+
+	if renders != 2 {
+		t.Errorf("expected rows to be reused, got %d renders", renders)
+	}
+	if got := rows[2].text.Value(); got != "bobby" {
+		t.Errorf("expected row to show the new name, got %q", got)
+	}
+}
+
+func TestSwitchOnlyBuildsTheActiveBranch(t *testing.T) {
+	first, second := Signal(false), Signal(true)
+	built := map[string]int{}
+	branch := func(name string) func() INode {
+		return func() INode {
+			built[name]++
+			return P().Text(name)
+		}
+	}
+	node := Switch(Match(first, branch("first")), Match(second, branch("second")), Default(branch("default")))
+
+	if got, want := renderString(node), "<!--[--><P>second</P><!--]-->"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if built["first"] != 0 || built["default"] != 0 || built["second"] != 1 {
+		t.Errorf("expected only the active branch to be built, got %v", built)
+	}
+}
+
+func TestSwitchDisposesThePreviousBranch(t *testing.T) {
+	key := Signal("a")
+	label := Signal("x")
+	var previous INode
+	SwitchOn(key, map[string]func() INode{
+		"a": func() INode {
+			previous = P().BindText(label)
+			return previous
+		},
+		"b": func() INode { return P().Text("b") },
+	}, nil)
+
+	key.Set("b")
+	label.Set("y")
+
+	if got := previous.AsVNode().text.Value(); got != "x" {
+		t.Errorf("expected previous branch to stop following the signal, got %q", got)
+	}
+}
+
+func TestSwitchFallback(t *testing.T) {
+	key := Signal("z")
+	node := SwitchOn(key, map[string]func() INode{
+		"a": func() INode { return P().Text("a") },
+	}, func() INode { return P().Text("fallback") })
+
+	if got, want := renderString(node), "<!--[--><P>fallback</P><!--]-->"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	key.Set("a")
+	if got, want := renderString(node), "<!--[--><P>a</P><!--]-->"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	empty := Switch(Match(Signal(false), func() INode { return P() }))
+	if got, want := renderString(empty), "<!--[--><!--]-->"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestEachMapRendersEntriesSortedByKey(t *testing.T) {
+	entries := Signal(map[string]int{"c": 3, "a": 1, "b": 2})
+	node := EachMap(entries, func(key string, value int) INode { return Li().Text(key) })
+
+	want := "<!--[--><LI>a</LI><LI>b</LI><LI>c</LI><!--]-->"
+	for range 5 {
+		if got := renderString(node); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+		entries.Set(maps.Clone(UntrackGet(entries)))
+	}
+}
@@ -0,0 +1,50 @@
+package hx
+
+import "log"
+
+type ElementRef interface {
+	set(element nativeElement)
+	clear()
+}
+
+type Ref[T any] struct {
+	current *SignalT[T]
+	present bool
+}
+
+func NewRef[T any]() *Ref[T] {
+	var zero T
+	return &Ref[T]{
+		current: Signal(zero),
+	}
+}
+
+func (ref *Ref[T]) Get() T {
+	return ref.current.Get()
+}
+
+func (ref *Ref[T]) Current() (T, bool) {
+	return UntrackGet[T](ref.current), ref.present
+}
+
+func (ref *Ref[T]) set(element nativeElement) {
+	value, ok := element.(T)
+	if !ok {
+		log.Printf("Warning: ref cannot hold element of type %T", element)
+		return
+	}
+	ref.present = true
+	ref.current.Set(value)
+}
+
+func (ref *Ref[T]) clear() {
+	var zero T
+	ref.present = false
+	ref.current.Set(zero)
+}
+
+func (element *VNode) Ref(ref ElementRef) INode {
+	element.OnMount(ref.set)
+	element.OnUnmount(ref.clear)
+	return element
+}
@@ -0,0 +1,53 @@
+//go:build !(js && wasm)
+
+package hx
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	renderLoopMu    sync.Mutex
+	renderLoopQueue []func()
+	renderLoopReady = make(chan struct{}, 1)
+)
+
+// RunOnRenderLoop queues fn for the goroutine that owns the signals.
+// Outside the browser there is no event loop, so that goroutine has to
+// run the queue with DrainRenderLoop or WaitRenderLoop.
+func RunOnRenderLoop(fn func()) {
+	renderLoopMu.Lock()
+	renderLoopQueue = append(renderLoopQueue, fn)
+	renderLoopMu.Unlock()
+	select {
+	case renderLoopReady <- struct{}{}:
+	default:
+	}
+}
+
+// DrainRenderLoop runs every queued callback and reports whether there
+// was any.
+func DrainRenderLoop() bool {
+	renderLoopMu.Lock()
+	queue := renderLoopQueue
+	renderLoopQueue = nil
+	renderLoopMu.Unlock()
+	for _, fn := range queue {
+		fn()
+	}
+	return len(queue) > 0
+}
+
+// WaitRenderLoop blocks until at least one callback is queued and runs
+// the queue, or returns the context error.
+func WaitRenderLoop(ctx context.Context) error {
+	for !DrainRenderLoop() {
+		select {
+		case <-renderLoopReady:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
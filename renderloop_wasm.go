@@ -0,0 +1,18 @@
+//go:build js && wasm
+
+package hx
+
+import "syscall/js"
+
+// RunOnRenderLoop queues fn to run on the JavaScript event loop, where
+// signals, effects and rendering live. Goroutines must use it to hand
+// their results back instead of setting signals directly.
+func RunOnRenderLoop(fn func()) {
+	var callback js.Func
+	callback = js.FuncOf(func(this js.Value, args []js.Value) any {
+		callback.Release()
+		fn()
+		return nil
+	})
+	js.Global().Call("queueMicrotask", callback)
+}
@@ -0,0 +1,98 @@
+package hx
+
+import "context"
+
+type ResourceT[K comparable, T any] struct {
+	Data    *SignalT[T]
+	Loading *SignalT[bool]
+	Error   *SignalT[error]
+
+	source  Gettable[K]
+	fetch   func(ctx context.Context, key K) (T, error)
+	cancel  context.CancelFunc
+	version int
+	effect  *Effect
+}
+
+// Resource calls fetch every time source changes. The context of the
+// previous call is cancelled and its result dropped, so only the latest
+// key ever lands in Data. Results are applied through RunOnRenderLoop.
+func Resource[K comparable, T any](source Gettable[K], fetch func(ctx context.Context, key K) (T, error)) *ResourceT[K, T] {
+	var zero T
+	resource := &ResourceT[K, T]{
+		Data:    Signal(zero),
+		Loading: Signal(false),
+		Error:   Signal[error](nil),
+		source:  source,
+		fetch:   fetch,
+	}
+	resource.effect = EffectFunc(func() {
+		key := source.Get()
+		OnCleanup(resource.cancelPending)
+		untrackOwned(func() {
+			resource.load(key)
+		})
+	})
+	return resource
+}
+
+func (resource *ResourceT[K, T]) load(key K) {
+	resource.cancelPending()
+	resource.version++
+	version := resource.version
+	ctx, cancel := context.WithCancel(context.Background())
+	resource.cancel = cancel
+	resource.Loading.Set(true)
+
+	go func() {
+		data, err := resource.fetch(ctx, key)
+		RunOnRenderLoop(func() {
+			if version != resource.version || ctx.Err() != nil {
+				return
+			}
+			resource.cancel = nil
+			cancel()
+			Batch(func() {
+				if err == nil {
+					resource.Data.Set(data)
+				}
+				resource.Error.Set(err)
+				resource.Loading.Set(false)
+			})
+		})
+	}()
+}
+
+func (resource *ResourceT[K, T]) cancelPending() {
+	if resource.cancel != nil {
+		resource.cancel()
+		resource.cancel = nil
+	}
+}
+
+// Get reads Data and lets the enclosing Suspense, if any, wait for the
+// resource while it is loading.
+func (resource *ResourceT[K, T]) Get() T {
+	var suspense *suspense
+	accessEffect(func(currentEffect *Effect) {
+		suspense = suspenseOf(currentEffect)
+	})
+	if suspense != nil {
+		suspense.track(resource.Loading)
+	}
+	return resource.Data.Get()
+}
+
+func (resource *ResourceT[K, T]) Refetch() {
+	resource.load(UntrackGet(resource.source))
+}
+
+// Mutate replaces Data right away, before the server confirms the change.
+// Call Refetch afterwards to reconcile with the real value.
+func (resource *ResourceT[K, T]) Mutate(data T) {
+	resource.Data.Set(data)
+}
+
+func (resource *ResourceT[K, T]) Dispose() {
+	resource.effect.Dispose()
+}
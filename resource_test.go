@@ -0,0 +1,66 @@
+//go:build !(js && wasm)
+
+package hx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func waitLoading[K comparable, T any](t *testing.T, resource *ResourceT[K, T]) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for UntrackGet[bool](resource.Loading) {
+		if err := WaitRenderLoop(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestResourceKeepsLatestKey(t *testing.T) {
+	id := Signal(1)
+	resource := Resource[int, int](id, func(ctx context.Context, key int) (int, error) {
+		if key == 1 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return key * 10, nil
+	})
+	defer resource.Dispose()
+
+	id.Set(2)
+	waitLoading(t, resource)
+
+	if got := UntrackGet[int](resource.Data); got != 20 {
+		t.Errorf("expected data 20, got %d", got)
+	}
+	if err := UntrackGet[error](resource.Error); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestResourceKeepsDataOnError(t *testing.T) {
+	fail := errors.New("boom")
+	id := Signal(1)
+	resource := Resource[int, string](id, func(ctx context.Context, key int) (string, error) {
+		if key == 2 {
+			return "", fail
+		}
+		return "one", nil
+	})
+	defer resource.Dispose()
+	waitLoading(t, resource)
+
+	id.Set(2)
+	waitLoading(t, resource)
+
+	if got := UntrackGet[string](resource.Data); got != "one" {
+		t.Errorf("expected previous data, got %q", got)
+	}
+	if err := UntrackGet[error](resource.Error); err != fail {
+		t.Errorf("expected %v, got %v", fail, err)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !(js && wasm)
+
+package router
+
+// There is no browser history outside the browser. The router starts at
+// "/" and only moves with Open, Navigate and Replace.
+
+func currentHref(mode Mode) string {
+	return "/"
+}
+
+func pushState(mode Mode, href string) {}
+
+func replaceState(mode Mode, href string) {}
+
+func listen(mode Mode, handler func(href string)) {}
@@ -0,0 +1,36 @@
+//go:build js && wasm
+
+package router
+
+import (
+	"strings"
+	"syscall/js"
+)
+
+func currentHref(mode Mode) string {
+	location := js.Global().Get("location")
+	if mode == Hash {
+		return strings.TrimPrefix(location.Get("hash").String(), "#")
+	}
+	return location.Get("pathname").String() + location.Get("search").String()
+}
+
+func pushState(mode Mode, href string) {
+	js.Global().Get("history").Call("pushState", nil, "", href)
+}
+
+func replaceState(mode Mode, href string) {
+	js.Global().Get("history").Call("replaceState", nil, "", href)
+}
+
+func listen(mode Mode, handler func(href string)) {
+	callback := js.FuncOf(func(this js.Value, args []js.Value) any {
+		handler(currentHref(mode))
+		return nil
+	})
+	window := js.Global().Get("window")
+	window.Call("addEventListener", "popstate", callback)
+	if mode == Hash {
+		window.Call("addEventListener", "hashchange", callback)
+	}
+}
@@ -0,0 +1,310 @@
+package router
+
+import (
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/deltegui/hx"
+)
+
+type Mode int
+
+const (
+	History Mode = iota
+	Hash
+)
+
+const maxRedirects = 8
+
+type Location struct {
+	Path     string
+	RawQuery string
+}
+
+func ParseLocation(href string) Location {
+	if index := strings.IndexByte(href, '#'); index >= 0 {
+		href = href[:index]
+	}
+	path, rawQuery, _ := strings.Cut(href, "?")
+	if len(path) == 0 || path[0] != '/' {
+		path = "/" + path
+	}
+	return Location{Path: path, RawQuery: rawQuery}
+}
+
+func (location Location) Query() url.Values {
+	values, _ := url.ParseQuery(location.RawQuery)
+	return values
+}
+
+func (location Location) String() string {
+	if len(location.RawQuery) == 0 {
+		return location.Path
+	}
+	return location.Path + "?" + location.RawQuery
+}
+
+// Guard runs before every navigation. Returning allow false cancels it,
+// unless redirect is set, in which case the router goes there instead.
+type Guard func(from, to Location) (redirect string, allow bool)
+
+// Route paths are relative to their parent. Segments starting with ':'
+// capture a param and a trailing "*" captures the rest of the path.
+type Route struct {
+	Path     string
+	View     func(outlet hx.INode) hx.INode
+	Children []Route
+	Guards   []Guard
+}
+
+type match struct {
+	location Location
+	routes   []*Route
+	params   map[string]string
+}
+
+type Router struct {
+	Location *hx.ComputedT[Location]
+
+	mode     Mode
+	routes   []Route
+	flat     []*Route
+	guards   []Guard
+	notFound func() hx.INode
+	current  *hx.SignalT[*match]
+}
+
+func New(mode Mode, routes ...Route) *Router {
+	router := &Router{
+		mode:   mode,
+		routes: routes,
+	}
+	router.flat = flatten(router.routes, nil)
+	router.current = hx.Signal(router.match(ParseLocation("/")))
+	router.Location = hx.Computed(func() Location {
+		return router.current.Get().location
+	})
+	return router
+}
+
+func flatten(routes []Route, flat []*Route) []*Route {
+	for index := range routes {
+		flat = append(flat, &routes[index])
+		flat = flatten(routes[index].Children, flat)
+	}
+	return flat
+}
+
+func (router *Router) NotFound(view func() hx.INode) *Router {
+	router.notFound = view
+	return router
+}
+
+func (router *Router) BeforeEach(guard Guard) *Router {
+	router.guards = append(router.guards, guard)
+	return router
+}
+
+// Start resolves the location the page was loaded with and follows the
+// browser back and forward buttons.
+func (router *Router) Start() *Router {
+	router.Open(currentHref(router.mode))
+	listen(router.mode, func(href string) {
+		if ParseLocation(href) == hx.UntrackGet[Location](router.Location) {
+			return
+		}
+		router.navigate(href, restoreEntry, 0)
+	})
+	return router
+}
+
+// Open resolves href without touching the browser history. Useful to
+// render a given path on the server.
+func (router *Router) Open(href string) {
+	router.navigate(href, keepEntry, 0)
+}
+
+func (router *Router) Navigate(href string) {
+	router.navigate(href, pushEntry, 0)
+}
+
+func (router *Router) Replace(href string) {
+	router.navigate(href, replaceEntry, 0)
+}
+
+type historyAction int
+
+const (
+	keepEntry historyAction = iota
+	pushEntry
+	replaceEntry
+	restoreEntry
+)
+
+func (router *Router) navigate(href string, action historyAction, hops int) {
+	if hops > maxRedirects {
+		log.Printf("Warning: too many redirects navigating to %s\n", href)
+		return
+	}
+	from := hx.UntrackGet[*match](router.current)
+	to := router.match(ParseLocation(href))
+
+	guards := append([]Guard{}, router.guards...)
+	for _, route := range to.routes {
+		guards = append(guards, route.Guards...)
+	}
+	for _, guard := range guards {
+		redirect, allow := guard(from.location, to.location)
+		if allow {
+			continue
+		}
+		if len(redirect) > 0 {
+			if action == pushEntry {
+				router.navigate(redirect, pushEntry, hops+1)
+			} else {
+				router.navigate(redirect, replaceEntry, hops+1)
+			}
+		} else if action == restoreEntry {
+			pushState(router.mode, router.href(from.location.String()))
+		}
+		return
+	}
+
+	switch action {
+	case pushEntry:
+		pushState(router.mode, router.href(to.location.String()))
+	case replaceEntry:
+		replaceState(router.mode, router.href(to.location.String()))
+	}
+	router.current.Set(to)
+}
+
+func (router *Router) match(location Location) *match {
+	params := map[string]string{}
+	segments := splitPath(location.Path)
+	routes, ok := matchRoutes(router.routes, segments, params)
+	if !ok {
+		return &match{location: location, params: map[string]string{}}
+	}
+	return &match{location: location, routes: routes, params: params}
+}
+
+func matchRoutes(routes []Route, segments []string, params map[string]string) ([]*Route, bool) {
+	for index := range routes {
+		route := &routes[index]
+		captured := map[string]string{}
+		rest, ok := matchSegments(splitPath(route.Path), segments, captured)
+		if !ok {
+			continue
+		}
+		if children, ok := matchRoutes(route.Children, rest, captured); ok {
+			for key, value := range captured {
+				params[key] = value
+			}
+			return append([]*Route{route}, children...), true
+		}
+		if len(rest) == 0 {
+			for key, value := range captured {
+				params[key] = value
+			}
+			return []*Route{route}, true
+		}
+	}
+	return nil, false
+}
+
+func matchSegments(pattern, segments []string, params map[string]string) ([]string, bool) {
+	for index, part := range pattern {
+		if part == "*" {
+			params["*"] = strings.Join(segments[min(index, len(segments)):], "/")
+			return nil, true
+		}
+		if index >= len(segments) {
+			return nil, false
+		}
+		segment, err := url.PathUnescape(segments[index])
+		if err != nil {
+			segment = segments[index]
+		}
+		if strings.HasPrefix(part, ":") {
+			params[part[1:]] = segment
+		} else if part != segment {
+			return nil, false
+		}
+	}
+	return segments[len(pattern):], true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if len(path) == 0 {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func (router *Router) href(path string) string {
+	if router.mode == Hash {
+		return "#" + path
+	}
+	return path
+}
+
+func (router *Router) Param(name string) *hx.ComputedT[string] {
+	return hx.Computed(func() string {
+		return router.current.Get().params[name]
+	})
+}
+
+func (router *Router) Query(name string) *hx.ComputedT[string] {
+	return hx.Computed(func() string {
+		return router.Location.Get().Query().Get(name)
+	})
+}
+
+// View renders the top level route. Every route view gets an outlet node
+// that renders the matched child route.
+func (router *Router) View() hx.INode {
+	return router.outlet(0)
+}
+
+func (router *Router) outlet(depth int) hx.INode {
+	matched := hx.Computed(func() *Route {
+		routes := router.current.Get().routes
+		if depth < len(routes) {
+			return routes[depth]
+		}
+		return nil
+	})
+	views := make(map[*Route]func() hx.INode, len(router.flat))
+	for _, route := range router.flat {
+		views[route] = func() hx.INode {
+			outlet := router.outlet(depth + 1)
+			if route.View == nil {
+				return outlet
+			}
+			return route.View(outlet)
+		}
+	}
+	var fallback func() hx.INode
+	if depth == 0 {
+		fallback = router.notFound
+	}
+	return hx.SwitchOn(matched, views, fallback)
+}
+
+// Link renders an anchor that navigates without reloading the page.
+// Clicks with modifiers or other buttons keep the browser behaviour.
+func (router *Router) Link(href string) *hx.AVNode {
+	link := hx.A().Href(router.href(href))
+	link.OnMouse(hx.EventClick, func(ctx hx.MouseEventContext) {
+		modifiers := ctx.Modifiers
+		if ctx.Button != 0 || modifiers.Alt || modifiers.Ctrl || modifiers.Meta || modifiers.Shift {
+			return
+		}
+		ctx.Event.PreventDefault()
+		router.Navigate(href)
+	})
+	return link
+}
@@ -0,0 +1,132 @@
+//go:build !(js && wasm)
+
+package router
+
+import (
+	"testing"
+
+	"github.com/deltegui/hx"
+)
+
+func renderString(node hx.INode) string {
+	renderer := &hx.StringRenderer{}
+	renderer.Render(node.AsVNode())
+	return renderer.String()
+}
+
+func newTestRouter(mode Mode) *Router {
+	return New(mode,
+		Route{Path: "/", View: func(outlet hx.INode) hx.INode { return hx.P().Text("home") }},
+		Route{Path: "/users", View: func(outlet hx.INode) hx.INode {
+			return hx.Div().Body(hx.H1().Text("users"), outlet)
+		}, Children: []Route{
+			{Path: "", View: func(outlet hx.INode) hx.INode { return hx.P().Text("list") }},
+			{Path: ":id", View: func(outlet hx.INode) hx.INode { return hx.P().Text("detail") }},
+		}},
+		Route{Path: "/files/*", View: func(outlet hx.INode) hx.INode { return hx.P().Text("files") }},
+	).NotFound(func() hx.INode { return hx.P().Text("not found") })
+}
+
+func TestNestedRoutesRenderInTheirOutlet(t *testing.T) {
+	router := newTestRouter(History)
+	view := router.View()
+
+	router.Open("/users/42")
+
+	if got, want := renderString(view), "<!--[--><DIV><H1>users</H1><!--[--><P>detail</P><!--]--></DIV><!--]-->"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if got := hx.UntrackGet[string](router.Param("id")); got != "42" {
+		t.Errorf("expected id 42, got %q", got)
+	}
+
+	router.Open("/users")
+
+	if got, want := renderString(view), "<!--[--><DIV><H1>users</H1><!--[--><P>list</P><!--]--></DIV><!--]-->"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestWildcardCapturesTheRestOfThePath(t *testing.T) {
+	router := newTestRouter(History)
+	router.Open("/files/docs/readme.md")
+
+	if got := hx.UntrackGet[string](router.Param("*")); got != "docs/readme.md" {
+		t.Errorf("expected docs/readme.md, got %q", got)
+	}
+}
+
+func TestQueryFollowsNavigation(t *testing.T) {
+	router := newTestRouter(History)
+	page := router.Query("page")
+
+	router.Navigate("/users?page=2")
+
+	if got := hx.UntrackGet[string](page); got != "2" {
+		t.Errorf("expected page 2, got %q", got)
+	}
+	if got := hx.UntrackGet[Location](router.Location).Path; got != "/users" {
+		t.Errorf("expected /users, got %q", got)
+	}
+}
+
+func TestUnknownPathRendersNotFound(t *testing.T) {
+	router := newTestRouter(History)
+	view := router.View()
+
+	router.Open("/nowhere")
+
+	if got, want := renderString(view), "<!--[--><P>not found</P><!--]-->"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestGuardRedirects(t *testing.T) {
+	router := newTestRouter(History).BeforeEach(func(from, to Location) (string, bool) {
+		if to.Path == "/users" {
+			return "/", false
+		}
+		return "", true
+	})
+	router.Navigate("/files/a")
+
+	router.Navigate("/users")
+
+	if got := hx.UntrackGet[Location](router.Location).Path; got != "/" {
+		t.Errorf("expected redirect to /, got %q", got)
+	}
+}
+
+func TestRedirectLoopStops(t *testing.T) {
+	calls := 0
+	router := newTestRouter(History).BeforeEach(func(from, to Location) (string, bool) {
+		if to.Path == "/" {
+			return "", true
+		}
+		calls++
+		if to.Path == "/users" {
+			return "/files/a", false
+		}
+		return "/users", false
+	})
+
+	router.Navigate("/users")
+
+	if calls != maxRedirects+1 {
+		t.Errorf("expected %d guard calls, got %d", maxRedirects+1, calls)
+	}
+	if got := hx.UntrackGet[Location](router.Location).Path; got != "/" {
+		t.Errorf("expected to stay at /, got %q", got)
+	}
+}
+
+func TestHashModeLinks(t *testing.T) {
+	router := newTestRouter(Hash)
+
+	if got := router.Link("/users").GetAttribute("href"); got != "#/users" {
+		t.Errorf("expected #/users, got %q", got)
+	}
+	if got := New(History).Link("/users").GetAttribute("href"); got != "/users" {
+		t.Errorf("expected /users, got %q", got)
+	}
+}
@@ -3,13 +3,17 @@ package hx
 import (
 	"fmt"
 	"runtime/debug"
+	"sort"
 	"sync"
 )
 
 var (
-	currentEffect *Effect
-	untrack       bool
-	mu            sync.Mutex
+	currentEffect  *Effect
+	untrack        bool
+	noTracking     bool
+	batchDepth     int
+	pendingEffects []*Effect
+	mu             sync.Mutex
 )
 
 func accessEffect(action func(*Effect)) {
@@ -18,6 +22,16 @@ func accessEffect(action func(*Effect)) {
 	mu.Unlock()
 }
 
+func accessTrackingEffect(action func(*Effect)) {
+	mu.Lock()
+	if noTracking {
+		action(nil)
+	} else {
+		action(currentEffect)
+	}
+	mu.Unlock()
+}
+
 type SignalT[T any] struct {
 	value       T
 	subscribers map[*Effect]struct{}
@@ -31,13 +45,13 @@ func Signal[T any](initial T) *SignalT[T] {
 }
 
 func (signal *SignalT[T]) Get() T {
-	accessEffect(func(currentEffect *Effect) {
+	accessTrackingEffect(func(currentEffect *Effect) {
 		if currentEffect != nil {
 			signal.subscribers[currentEffect] = struct{}{}
 			currentEffect.cleanUps = append(currentEffect.cleanUps, func() {
 				delete(signal.subscribers, currentEffect)
 			})
-		} else if !untrack {
+		} else if !untrack && !noTracking {
 			fmt.Printf("Cannot call Signal.Get if there is no effect in %s\n", debug.Stack())
 		}
 	})
@@ -46,7 +60,7 @@ func (signal *SignalT[T]) Get() T {
 
 func (signal *SignalT[T]) Set(v T) {
 	signal.value = v
-	signal.notify()
+	Batch(signal.notify)
 }
 
 func (signal *SignalT[T]) Update(fn func(T) T) {
@@ -55,51 +69,89 @@ func (signal *SignalT[T]) Update(fn func(T) T) {
 
 func (signal *SignalT[T]) notify() {
 	for effect := range signal.subscribers {
-		effect.schedule()
+		effect.markStale(stateDirty)
 	}
 }
 
+type effectState int
+
+const (
+	stateClean effectState = iota
+	stateCheck
+	stateDirty
+)
+
 type Effect struct {
 	fn          func()
 	isScheduled bool
+	state       effectState
+	height      int
+	disposed    bool
+	owner       *Effect
+	parent      *Effect
+	scope       map[any]any
+	onStale     func()
+	sources     []*Effect
 	childs      []*Effect
 	cleanUps    []func()
 }
 
 func EffectFunc(fn func()) *Effect {
+	e := newEffect(fn)
+	Batch(e.run)
+	return e
+}
+
+func newEffect(fn func()) *Effect {
 	e := &Effect{
 		fn:       fn,
+		state:    stateClean,
+		sources:  make([]*Effect, 0),
 		childs:   make([]*Effect, 0),
 		cleanUps: make([]func(), 0),
 	}
 	accessEffect(func(currentEffect *Effect) {
 		if currentEffect != nil {
 			currentEffect.childs = append(currentEffect.childs, e)
+			e.owner = currentEffect
+			e.parent = currentEffect
+			e.height = currentEffect.height + 1
 		}
 	})
-	e.run()
 	return e
 }
 
 func (e *Effect) run() {
+	boundary := boundaryOf(e)
+	if err := boundary.capture(e.runTracked); err != nil {
+		boundary.fail(err)
+	}
+}
+
+func (e *Effect) runTracked() {
 	mu.Lock()
 	prev := currentEffect
+	prevNoTracking := noTracking
 	currentEffect = e
+	noTracking = false
 	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		currentEffect = prev
+		noTracking = prevNoTracking
+		mu.Unlock()
+	}()
 
 	e.clean()
 	e.fn()
-
-	mu.Lock()
-	currentEffect = prev
-	mu.Unlock()
 }
 
 func (e *Effect) clean() {
 	for _, child := range e.childs {
-		child.clean()
+		child.dispose()
 	}
 	e.childs = []*Effect{}
+	e.sources = []*Effect{}
 
 	for _, cleanfn := range e.cleanUps {
 		cleanfn()
@@ -108,13 +160,169 @@ func (e *Effect) clean() {
 
 }
 
+func (e *Effect) dispose() {
+	e.clean()
+	e.disposed = true
+	e.owner = nil
+}
+
+func (e *Effect) Dispose() {
+	if e.disposed {
+		return
+	}
+	if e.owner != nil {
+		e.owner.childs = removeOrderedItem(e.owner.childs, e)
+	}
+	e.dispose()
+}
+
+func CreateRoot(fn func(dispose func())) {
+	root := newRoot()
+
+	mu.Lock()
+	prev := currentEffect
+	prevNoTracking := noTracking
+	root.parent = prev
+	currentEffect = root
+	noTracking = true
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		currentEffect = prev
+		noTracking = prevNoTracking
+		mu.Unlock()
+	}()
+
+	fn(root.Dispose)
+}
+
+// runInScope runs fn under an effect owned by the current one that carries
+// value for key. Effects created inside fn can find it with lookupScope.
+func runInScope(key, value any, fn func()) {
+	host := newEffect(func() {})
+	host.scope = map[any]any{key: value}
+
+	mu.Lock()
+	prev := currentEffect
+	prevNoTracking := noTracking
+	currentEffect = host
+	noTracking = true
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		currentEffect = prev
+		noTracking = prevNoTracking
+		mu.Unlock()
+	}()
+
+	fn()
+}
+
+func lookupScope(from *Effect, key any) any {
+	for effect := from; effect != nil; effect = effect.parent {
+		if value, ok := effect.scope[key]; ok {
+			return value
+		}
+	}
+	return nil
+}
+
+func newRoot() *Effect {
+	return &Effect{
+		fn:       func() {},
+		state:    stateClean,
+		sources:  make([]*Effect, 0),
+		childs:   make([]*Effect, 0),
+		cleanUps: make([]func(), 0),
+	}
+}
+
+func OnCleanup(fn func()) {
+	accessEffect(func(currentEffect *Effect) {
+		if currentEffect != nil {
+			currentEffect.cleanUps = append(currentEffect.cleanUps, fn)
+		}
+	})
+}
+
+func (e *Effect) dependOn(source *Effect) {
+	e.sources = append(e.sources, source)
+	if source.height >= e.height {
+		e.height = source.height + 1
+	}
+}
+
+func (e *Effect) markStale(state effectState) {
+	if e.disposed || e.state >= state {
+		return
+	}
+	previous := e.state
+	e.state = state
+	if e.onStale != nil {
+		if previous == stateClean {
+			e.onStale()
+		}
+		return
+	}
+	e.schedule()
+}
+
+func (e *Effect) update() {
+	if e.disposed {
+		return
+	}
+	if e.state == stateCheck {
+		for _, source := range e.sources {
+			source.update()
+			if e.state == stateDirty {
+				break
+			}
+		}
+	}
+	if e.state == stateDirty {
+		e.run()
+	}
+	e.state = stateClean
+}
+
 func (e *Effect) schedule() {
 	if e.isScheduled {
 		return
 	}
 	e.isScheduled = true
-	e.run()
-	e.isScheduled = false
+	pendingEffects = append(pendingEffects, e)
+	if batchDepth == 0 {
+		flushEffects()
+	}
+}
+
+func Batch(fn func()) {
+	batchDepth++
+	defer func() {
+		batchDepth--
+		if batchDepth == 0 {
+			flushEffects()
+		}
+	}()
+	fn()
+}
+
+func flushEffects() {
+	batchDepth++
+	defer func() {
+		batchDepth--
+	}()
+	for len(pendingEffects) > 0 {
+		queue := pendingEffects
+		pendingEffects = nil
+		sort.SliceStable(queue, func(i, j int) bool {
+			return queue[i].height < queue[j].height
+		})
+		for _, effect := range queue {
+			effect.update()
+			effect.isScheduled = false
+		}
+	}
 }
 
 type ComputedT[T comparable] struct {
@@ -128,21 +336,32 @@ func Computed[T comparable](fn func() T) *ComputedT[T] {
 		subscribers: make(map[*Effect]struct{}),
 	}
 
-	c.dependentEffect = EffectFunc(func() {
+	c.dependentEffect = newEffect(func() {
 		newVal := fn()
 		if newVal != c.value {
 			c.value = newVal
 			c.notify()
 		}
 	})
+	c.dependentEffect.onStale = func() {
+		for effect := range c.subscribers {
+			effect.markStale(stateCheck)
+		}
+	}
+	c.dependentEffect.run()
 
 	return c
 }
 
 func (c *ComputedT[T]) Get() T {
-	accessEffect(func(currentEffect *Effect) {
+	c.dependentEffect.update()
+	accessTrackingEffect(func(currentEffect *Effect) {
 		if currentEffect != nil {
 			c.subscribers[currentEffect] = struct{}{}
+			currentEffect.dependOn(c.dependentEffect)
+			currentEffect.cleanUps = append(currentEffect.cleanUps, func() {
+				delete(c.subscribers, currentEffect)
+			})
 		}
 	})
 	return c.value
@@ -150,40 +369,56 @@ func (c *ComputedT[T]) Get() T {
 
 func (c *ComputedT[T]) notify() {
 	for effect := range c.subscribers {
-		effect.schedule()
+		effect.markStale(stateDirty)
 	}
 }
 
 func Untrack(fn func()) {
 	mu.Lock()
 	prev := currentEffect
+	prevUntrack := untrack
 	untrack = true
 	currentEffect = nil
 	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		currentEffect = prev
+		untrack = prevUntrack
+		mu.Unlock()
+	}()
 
 	fn()
+}
 
+func untrackOwned(fn func()) {
 	mu.Lock()
-	currentEffect = prev
-	untrack = false
+	prev := noTracking
+	noTracking = true
 	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		noTracking = prev
+		mu.Unlock()
+	}()
+
+	fn()
 }
 
 func UntrackGet[T any](gettable Gettable[T]) T {
 	mu.Lock()
 	prev := currentEffect
+	prevUntrack := untrack
 	untrack = true
 	currentEffect = nil
 	mu.Unlock()
-
-	value := gettable.Get()
-
-	mu.Lock()
-	currentEffect = prev
-	untrack = false
-	mu.Unlock()
-
-	return value
+	defer func() {
+		mu.Lock()
+		currentEffect = prev
+		untrack = prevUntrack
+		mu.Unlock()
+	}()
+
+	return gettable.Get()
 }
 
 type Gettable[T any] interface {
@@ -193,3 +428,8 @@ type Gettable[T any] interface {
 type Settable[T any] interface {
 	Set(T)
 }
+
+type Bindable[T any] interface {
+	Gettable[T]
+	Settable[T]
+}
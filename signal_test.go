@@ -0,0 +1,76 @@
+package hx
+
+import "testing"
+
+func TestDiamondRunsEffectOnceWithConsistentValues(t *testing.T) {
+	a := Signal(1)
+	b := Computed(func() int { return a.Get() * 2 })
+	c := Computed(func() int { return a.Get() + 10 })
+
+	runs := 0
+	var seen [][2]int
+	EffectFunc(func() {
+		runs++
+		seen = append(seen, [2]int{b.Get(), c.Get()})
+	})
+
+	a.Set(2)
+
+	if runs != 2 {
+		t.Fatalf("expected 2 runs, got %d: %v", runs, seen)
+	}
+	if last := seen[len(seen)-1]; last != [2]int{4, 12} {
+		t.Errorf("expected [4 12], got %v", last)
+	}
+}
+
+func TestComputedSkipsDependentsWhenValueIsUnchanged(t *testing.T) {
+	a := Signal(1)
+	parity := Computed(func() bool { return a.Get()%2 == 0 })
+
+	runs := 0
+	EffectFunc(func() {
+		parity.Get()
+		runs++
+	})
+
+	a.Set(3)
+
+	if runs != 1 {
+		t.Errorf("expected 1 run, got %d", runs)
+	}
+}
+
+func TestBatchRunsEffectsOnce(t *testing.T) {
+	first := Signal("a")
+	last := Signal("b")
+
+	runs := 0
+	EffectFunc(func() {
+		first.Get()
+		last.Get()
+		runs++
+	})
+
+	Batch(func() {
+		first.Set("c")
+		last.Set("d")
+	})
+
+	if runs != 2 {
+		t.Errorf("expected 2 runs, got %d", runs)
+	}
+}
+
+func TestNestedUntrackGetKeepsUntracking(t *testing.T) {
+	first := Signal("a")
+	Untrack(func() {
+		UntrackGet[string](first)
+		if !untrack {
+			t.Error("expected Untrack to stay in effect after UntrackGet")
+		}
+	})
+	if untrack {
+		t.Error("expected untrack to be restored after Untrack")
+	}
+}
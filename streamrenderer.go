@@ -0,0 +1,66 @@
+package hx
+
+import (
+	"bytes"
+	"io"
+)
+
+const defaultFlushSize = 4096
+
+// StreamRenderer writes the tree as HTML to an io.Writer. As with
+// StringRenderer, build the tree inside CreateRoot and dispose it afterwards.
+type StreamRenderer struct {
+	target    io.Writer
+	chunk     bytes.Buffer
+	flushSize int
+	err       error
+}
+
+func NewStreamRenderer(target io.Writer, flushSize int) *StreamRenderer {
+	if flushSize <= 0 {
+		flushSize = defaultFlushSize
+	}
+	return &StreamRenderer{
+		target:    target,
+		flushSize: flushSize,
+	}
+}
+
+func (sr *StreamRenderer) ScheduleRender()     {}
+func (sr *StreamRenderer) Mark(element *VNode) {}
+
+func (sr *StreamRenderer) Render(current *VNode) error {
+	markup := markupWriter{
+		out: &sr.chunk,
+		boundary: func() error {
+			if sr.chunk.Len() < sr.flushSize {
+				return nil
+			}
+			return sr.Flush()
+		},
+	}
+	markup.render(current)
+	if markup.err != nil && sr.err == nil {
+		sr.err = markup.err
+	}
+	return sr.Flush()
+}
+
+func (sr *StreamRenderer) Flush() error {
+	if sr.err != nil {
+		return sr.err
+	}
+	if sr.chunk.Len() > 0 {
+		if _, err := sr.chunk.WriteTo(sr.target); err != nil {
+			sr.err = err
+			return err
+		}
+	}
+	switch flusher := sr.target.(type) {
+	case interface{ Flush() error }:
+		sr.err = flusher.Flush()
+	case interface{ Flush() }:
+		flusher.Flush()
+	}
+	return sr.err
+}
@@ -0,0 +1,77 @@
+package hx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type chunkRecorder struct {
+	chunks []string
+}
+
+func (w *chunkRecorder) Write(p []byte) (int, error) {
+	w.chunks = append(w.chunks, string(p))
+	return len(p), nil
+}
+
+type failingWriter struct {
+	writes int
+	err    error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes >= 2 {
+		return 0, w.err
+	}
+	return len(p), nil
+}
+
+func streamTestTree() *VNode {
+	return Ul().Body(
+		Li().Text("first row"),
+		Li().Text("second row"),
+		Li().Text("third row"),
+	).AsVNode()
+}
+
+func TestStreamRendererFlushesAtFlushSize(t *testing.T) {
+	out := &chunkRecorder{}
+	renderer := NewStreamRenderer(out, 20)
+
+	if err := renderer.Render(streamTestTree()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out.chunks) < 2 {
+		t.Fatalf("expected several chunks, got %q", out.chunks)
+	}
+	for _, chunk := range out.chunks[:len(out.chunks)-1] {
+		if len(chunk) < 20 {
+			t.Errorf("expected chunks of at least 20 bytes, got %q", chunk)
+		}
+	}
+	if got, want := strings.Join(out.chunks, ""), renderString(streamTestTree()); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestStreamRendererKeepsFirstWriteError(t *testing.T) {
+	fail := errors.New("closed")
+	out := &failingWriter{err: fail}
+	renderer := NewStreamRenderer(out, 20)
+
+	if err := renderer.Render(streamTestTree()); !errors.Is(err, fail) {
+		t.Fatalf("expected %v, got %v", fail, err)
+	}
+	if out.writes != 2 {
+		t.Errorf("expected writing to stop after the error, got %d writes", out.writes)
+	}
+	if err := renderer.Render(streamTestTree()); !errors.Is(err, fail) {
+		t.Errorf("expected the error to be kept, got %v", err)
+	}
+	if out.writes != 2 {
+		t.Errorf("expected no more writes, got %d", out.writes)
+	}
+}
@@ -1,6 +1,72 @@
 package hx
 
+import "reflect"
+
 func removeListItem[T any](s []T, i int) []T {
 	s[i] = s[len(s)-1]
 	return s[:len(s)-1]
 }
+
+func removeOrderedItem[T comparable](s []T, item T) []T {
+	for index, current := range s {
+		if current == item {
+			return append(s[:index], s[index+1:]...)
+		}
+	}
+	return s
+}
+
+func longestIncreasingSubsequence(positions []int) []bool {
+	tails := []int{}
+	previous := make([]int, len(positions))
+	for index, position := range positions {
+		previous[index] = -1
+		if position < 0 {
+			continue
+		}
+		low, high := 0, len(tails)
+		for low < high {
+			middle := (low + high) / 2
+			if positions[tails[middle]] < position {
+				low = middle + 1
+			} else {
+				high = middle
+			}
+		}
+		if low > 0 {
+			previous[index] = tails[low-1]
+		}
+		if low == len(tails) {
+			tails = append(tails, index)
+		} else {
+			tails[low] = index
+		}
+	}
+
+	result := make([]bool, len(positions))
+	if len(tails) == 0 {
+		return result
+	}
+	for index := tails[len(tails)-1]; index >= 0; index = previous[index] {
+		result[index] = true
+	}
+	return result
+}
+
+// isComparable reports whether value can be used with == without panicking.
+func isComparable(value any) bool {
+	return value == nil || reflect.ValueOf(value).Comparable()
+}
+
+// isPropertyValue reports whether value can be handed to the DOM as a
+// property, which only takes the types js.ValueOf understands.
+func isPropertyValue(value any) bool {
+	switch value.(type) {
+	case nil, bool, string,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64, uintptr,
+		float32, float64, []any, map[string]any:
+		return true
+	}
+	return isNativeValue(value)
+}
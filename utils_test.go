@@ -0,0 +1,41 @@
+package hx
+
+import "testing"
+
+func TestLongestIncreasingSubsequence(t *testing.T) {
+	tests := []struct {
+		positions []int
+		length    int
+	}{
+		{nil, 0},
+		{[]int{-1, -1}, 0},
+		{[]int{0, 1, 2}, 3},
+		{[]int{2, 1, 0}, 1},
+		{[]int{-1, 0, -1, 1}, 2},
+		{[]int{3, 0, 1, 2}, 3},
+		{[]int{1, 3, 0, 2, 4}, 3},
+		{[]int{4, -1, 0, 5, 1, 2, -1, 3}, 4},
+	}
+	for _, test := range tests {
+		stable := longestIncreasingSubsequence(test.positions)
+		if len(stable) != len(test.positions) {
+			t.Fatalf("positions %v: expected %d flags, got %d", test.positions, len(test.positions), len(stable))
+		}
+		length, last := 0, -1
+		for index, keep := range stable {
+			if !keep {
+				continue
+			}
+			position := test.positions[index]
+			if position < 0 || position <= last {
+				t.Errorf("positions %v: %v is not an increasing subsequence", test.positions, stable)
+				break
+			}
+			last = position
+			length++
+		}
+		if length != test.length {
+			t.Errorf("positions %v: expected length %d, got %d", test.positions, test.length, length)
+		}
+	}
+}
@@ -60,6 +60,9 @@ func (sv *diffValue[T]) assign(val T, status changeStatus) bool {
 		return false
 	}
 	if val == sv.nextValue {
+		if sv.status == changeDeleted {
+			sv.status = status
+		}
 		return true
 	}
 	sv.nextValue = val
@@ -71,6 +74,12 @@ func (sv *diffValue[T]) equals(val T) bool {
 	return sv.nextValue == val || sv.value == val
 }
 
+func (sv *diffValue[T]) sync(val T) {
+	sv.value = val
+	sv.nextValue = val
+	sv.status = unchanged
+}
+
 func (sv *diffValue[T]) tick() {
 	sv.value = sv.nextValue
 	sv.status = unchanged